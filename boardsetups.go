@@ -1,3 +1,6 @@
+//go:build !gardner && !board8x8
+// +build !gardner,!board8x8
+
 package main
 
 import "github.com/an1jay/los-alamos-chess/game"
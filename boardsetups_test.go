@@ -0,0 +1,39 @@
+//go:build !gardner && !board8x8
+// +build !gardner,!board8x8
+
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/an1jay/los-alamos-chess/game"
+)
+
+// TestBoardSetupsPerft checks that game.PerftResults covers every setup in boardsetups.go, so that the known-good
+// counts are checked for each of them by the game package's tests.
+func TestBoardSetupsPerft(t *testing.T) {
+	setups := map[string]map[game.Square]game.Piece{
+		"NewGame":        NewGame,
+		"PuzzlingPos":    PuzzlingPos,
+		"PuzzlingPos2":   PuzzlingPos2,
+		"InterestingPos": InterestingPos,
+		"CheckPos":       CheckPos,
+		"KNPawnGame":     KNPawnGame,
+		"KNPawnOpening":  KNPawnOpening,
+	}
+	for name, setup := range setups {
+		found := false
+		for _, pr := range game.PerftResults {
+			if pr.Name == name {
+				found = true
+				if got, want := game.BoardFromMap(setup).FEN(), strings.Fields(pr.FEN)[0]; got != want {
+					t.Errorf("%s: setup is %s, perft counts are for %s", name, got, want)
+				}
+			}
+		}
+		if !found {
+			t.Errorf("%s: no perft counts", name)
+		}
+	}
+}
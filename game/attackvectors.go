@@ -1,3 +1,6 @@
+//go:build !gardner && !board8x8
+// +build !gardner,!board8x8
+
 package game
 
 // The i'th element of these arrays is the bitboard for the moves available for a piece located at Square(i)
@@ -3,6 +3,7 @@ package game
 import (
 	"fmt"
 	"math/bits"
+	"strings"
 )
 
 // Bitboard encodes as following:
@@ -11,7 +12,7 @@ import (
 //		Number					| 35 34 33 32 31 30 | 29 28 27 26 25 24 | 23 22 21 20 19 18 | 17 16 15 14 13 12 | 11 10 9  8  7  6  | 5  4  3  2  1  0
 
 // BitBoard is a board representation encoded in an unsigned 64-bit integer.
-// Stores the 36 squares in the 36 least significant bits. All other bits are zero.
+// Stores the 36 squares in the 36 least significant bits (numSquaresInBoard on other boards). All other bits are zero.
 type BitBoard uint64
 
 // BitBoardFromMap generates a bitboard, from a Square->bool map
@@ -86,7 +87,7 @@ func (b BitBoard) Occupied(sq Square) bool {
 
 // Reverse returns a reversed version of the bitboard - useful in calculating legal moves
 func (b BitBoard) Reverse() BitBoard {
-	return BitBoard(bits.Reverse64(uint64(b)) >> 28)
+	return BitBoard(bits.Reverse64(uint64(b)) >> (64 - numSquaresInBoard))
 }
 
 // String returns a 36 character string of 1s and 0s starting with most significant bit.
@@ -97,20 +98,18 @@ func (b BitBoard) String() string {
 
 // FuncDisplay outputs a string representation given by function f to stdout
 func (b BitBoard) FuncDisplay(f func(Square) string) {
-	fmt.Printf(
-		"   A B C D E F\n"+
-			"6. %s %s %s %s %s %s\n"+
-			"5. %s %s %s %s %s %s\n"+
-			"4. %s %s %s %s %s %s\n"+
-			"3. %s %s %s %s %s %s\n"+
-			"2. %s %s %s %s %s %s\n"+
-			"1. %s %s %s %s %s %s\n",
-		f(A6), f(B6), f(C6), f(D6), f(E6), f(F6),
-		f(A5), f(B5), f(C5), f(D5), f(E5), f(F5),
-		f(A4), f(B4), f(C4), f(D4), f(E4), f(F4),
-		f(A3), f(B3), f(C3), f(D3), f(E3), f(F3),
-		f(A2), f(B2), f(C2), f(D2), f(E2), f(F2),
-		f(A1), f(B1), f(C1), f(D1), f(E1), f(F1))
+	fmt.Print("  ")
+	for fl := FileA; fl < numSquaresInRow; fl++ {
+		fmt.Print(" " + strings.ToUpper(fl.String()))
+	}
+	fmt.Println()
+	for r := lastRank; r >= Rank1; r-- {
+		fmt.Print(r.String() + ".")
+		for fl := FileA; fl < numSquaresInRow; fl++ {
+			fmt.Print(" " + f(GetSquare(fl, r)))
+		}
+		fmt.Println()
+	}
 }
 
 // Display prints a representation to stdout.
@@ -121,55 +120,42 @@ func (b BitBoard) Display() {
 //--------------------------------------------------------------------------------
 
 const (
-	// BBMask is equal to 0000000000000000000000000000111111111111111111111111111111111111
-	BBMask BitBoard = 68719476735
+	// BBMask has the bits of every square on the board set.
+	// Equal to 0000000000000000000000000000111111111111111111111111111111111111 on the 6x6 board
+	BBMask BitBoard = 1<<numSquaresInBoard - 1
 
 	// BBValidityCheck is used to check whether a bitboard is valid
 	// Any bitboard b is only valid iff b & validBBCheck == 0
-	// Equal to 1111111111111111111111111111000000000000000000000000000000000000
-	BBValidityCheck BitBoard = 18446744004990074880
-)
-
-// Bitboards for each file
-const (
-	BBFileA BitBoard = 1090785345
-	BBFileB BitBoard = 2181570690
-	BBFileC BitBoard = 4363141380
-	BBFileD BitBoard = 8726282760
-	BBFileE BitBoard = 17452565520
-	BBFileF BitBoard = 34905131040
+	// Equal to 1111111111111111111111111111000000000000000000000000000000000000 on the 6x6 board
+	BBValidityCheck BitBoard = ^BBMask
 )
 
-// Bitboards for each rank
-const (
-	BBRank1 BitBoard = 63
-	BBRank2 BitBoard = 4032
-	BBRank3 BitBoard = 258048
-	BBRank4 BitBoard = 16515072
-	BBRank5 BitBoard = 1056964608
-	BBRank6 BitBoard = 67645734912
-)
 const (
-	// NumSquaresInBoard is 36, the number of squares on the board
-	NumSquaresInBoard int = 36
-	// NumSquaresInRow is 6, the number of squares in a row
-	NumSquaresInRow int = 6
+	// NumSquaresInBoard is the number of squares on the board - 36 on the 6x6 board
+	NumSquaresInBoard int = numSquaresInBoard
+	// NumSquaresInRow is the number of squares in a row - 6 on the 6x6 board
+	NumSquaresInRow int = numSquaresInRow
 )
 
 var (
 	// EmptyBitBoardMap is a map from all Squares to false - i.e. representing an empty bitboard.
-	EmptyBitBoardMap = map[Square]bool{
-		A1: false, B1: false, C1: false, D1: false, E1: false, F1: false,
-		A2: false, B2: false, C2: false, D2: false, E2: false, F2: false,
-		A3: false, B3: false, C3: false, D3: false, E3: false, F3: false,
-		A4: false, B4: false, C4: false, D4: false, E4: false, F4: false,
-		A5: false, B5: false, C5: false, D5: false, E5: false, F5: false,
-		A6: false, B6: false, C6: false, D6: false, E6: false, F6: false,
-	}
-
-	// BBFiles is an array of bitboards for each file
-	BBFiles = [6]BitBoard{BBFileA, BBFileB, BBFileC, BBFileD, BBFileE, BBFileF}
+	EmptyBitBoardMap = map[Square]bool{}
 
-	// BBRanks is an array of bitboards for each rank
-	BBRanks = [6]BitBoard{BBRank1, BBRank2, BBRank3, BBRank4, BBRank5, BBRank6}
+	// BBFiles and BBRanks are arrays of bitboards for each file and each rank
+	BBFiles, BBRanks = fileAndRankBitBoards()
 )
+
+func init() {
+	for sq := Square(0); sq < numSquaresInBoard; sq++ {
+		EmptyBitBoardMap[sq] = false
+	}
+}
+
+// fileAndRankBitBoards returns the bitboards for each file and each rank.
+func fileAndRankBitBoards() (files, ranks [numSquaresInRow]BitBoard) {
+	for sq := Square(0); sq < numSquaresInBoard; sq++ {
+		files[sq.File()] |= sq.BitBoard()
+		ranks[sq.Rank()] |= sq.BitBoard()
+	}
+	return files, ranks
+}
@@ -3,6 +3,7 @@ package game
 import (
 	"fmt"
 	"math/bits"
+	"strings"
 )
 
 // Board represents the pieces on the Los Alamos chess board
@@ -12,12 +13,14 @@ type Board struct {
 	wRooks   BitBoard
 	wQueen   BitBoard
 	wKing    BitBoard
+	wBishops BitBoard
 
 	bPawns   BitBoard
 	bKnights BitBoard
 	bRooks   BitBoard
 	bQueen   BitBoard
 	bKing    BitBoard
+	bBishops BitBoard
 
 	bPieces  BitBoard
 	wPieces  BitBoard
@@ -39,11 +42,33 @@ func (b *Board) SquareMap() map[Square]Piece {
 
 // Piece returns the piece at that square
 func (b *Board) Piece(sq Square) Piece {
-	for _, p := range AllPieces {
-		bb := b.BitBoardForPiece(p)
-		if bb.Occupied(sq) {
-			return p
-		}
+	// checked in the order of AllPieces, without looping over it, as this is called for every move made
+	bb := sq.BitBoard()
+	switch {
+	case b.wPawns&bb != 0:
+		return WhitePawn
+	case b.wKnights&bb != 0:
+		return WhiteKnight
+	case b.wRooks&bb != 0:
+		return WhiteRook
+	case b.wQueen&bb != 0:
+		return WhiteQueen
+	case b.wKing&bb != 0:
+		return WhiteKing
+	case b.bPawns&bb != 0:
+		return BlackPawn
+	case b.bKnights&bb != 0:
+		return BlackKnight
+	case b.bRooks&bb != 0:
+		return BlackRook
+	case b.bQueen&bb != 0:
+		return BlackQueen
+	case b.bKing&bb != 0:
+		return BlackKing
+	case b.wBishops&bb != 0:
+		return WhiteBishop
+	case b.bBishops&bb != 0:
+		return BlackBishop
 	}
 	return NoPiece
 }
@@ -64,27 +89,20 @@ func (b *Board) SquareDisplay() {
 
 // FuncDisplay outputs a string representation given by function f to stdout
 func (b *Board) FuncDisplay(f func(Square) string) {
-	fmt.Printf(
-		" -+---+---+---+---+---+---+\n"+
-			"6 | %s | %s | %s | %s | %s | %s |\n"+
-			" -+---+---+---+---+---+---+\n"+
-			"5 | %s | %s | %s | %s | %s | %s |\n"+
-			" -+---+---+---+---+---+---+\n"+
-			"4 | %s | %s | %s | %s | %s | %s |\n"+
-			" -+---+---+---+---+---+---+\n"+
-			"3 | %s | %s | %s | %s | %s | %s |\n"+
-			" -+---+---+---+---+---+---+\n"+
-			"2 | %s | %s | %s | %s | %s | %s |\n"+
-			" -+---+---+---+---+---+---+\n"+
-			"1 | %s | %s | %s | %s | %s | %s |\n"+
-			" -+---+---+---+---+---+---+\n"+
-			"    A   B   C   D   E   F\n",
-		f(A6), f(B6), f(C6), f(D6), f(E6), f(F6),
-		f(A5), f(B5), f(C5), f(D5), f(E5), f(F5),
-		f(A4), f(B4), f(C4), f(D4), f(E4), f(F4),
-		f(A3), f(B3), f(C3), f(D3), f(E3), f(F3),
-		f(A2), f(B2), f(C2), f(D2), f(E2), f(F2),
-		f(A1), f(B1), f(C1), f(D1), f(E1), f(F1))
+	line := " -" + strings.Repeat("+---", numSquaresInRow) + "+\n"
+	fmt.Print(line)
+	for r := lastRank; r >= Rank1; r-- {
+		fmt.Print(r.String() + " |")
+		for fl := FileA; fl < numSquaresInRow; fl++ {
+			fmt.Print(" " + f(GetSquare(fl, r)) + " |")
+		}
+		fmt.Print("\n" + line)
+	}
+	fmt.Print(" ")
+	for fl := FileA; fl < numSquaresInRow; fl++ {
+		fmt.Print("   " + strings.ToUpper(fl.String()))
+	}
+	fmt.Println()
 }
 
 // BoardFromMap generates a NewBoard from a Square->Piece map
@@ -101,7 +119,7 @@ func BoardFromMap(m map[Square]Piece) *Board {
 	return &b
 }
 
-// CheckBBsAreValid checks that the most significant 28 bits are zero.
+// CheckBBsAreValid checks that the bits outside the board (the most significant 28 on the 6x6 board) are zero.
 func (b *Board) CheckBBsAreValid() bool {
 	rv := true
 	for _, p := range AllPieces {
@@ -118,7 +136,7 @@ func (b *Board) DebugBitBoards() {
 	}
 }
 
-// BitBoardForPiece returns a copy of the bitboard for piece p
+// BitBoardForPiece returns a copy of the bitboard for piece p, or an empty bitboard if p is not a piece
 func (b *Board) BitBoardForPiece(p Piece) BitBoard {
 	switch p {
 	case WhitePawn:
@@ -141,8 +159,12 @@ func (b *Board) BitBoardForPiece(p Piece) BitBoard {
 		return b.bQueen
 	case BlackKing:
 		return b.bKing
+	case WhiteBishop:
+		return b.wBishops
+	case BlackBishop:
+		return b.bBishops
 	}
-	return BitBoard(1 << 63)
+	return BitBoard(0)
 }
 
 // MaterialCount returns number of ones on the bitboard for piece p
@@ -177,6 +199,10 @@ func (b *Board) SetBBForPiece(p Piece, bb BitBoard) {
 		b.bQueen = bb
 	case BlackKing:
 		b.bKing = bb
+	case WhiteBishop:
+		b.wBishops = bb
+	case BlackBishop:
+		b.bBishops = bb
 	default:
 		panic("Board.SetBBForPiece() -> Invalid Piece")
 	}
@@ -193,11 +219,18 @@ func (b *Board) PieceOccupancy(c Color) BitBoard {
 
 // UpdateConvenienceBBs updates the wPieces, bPieces and emptySqs bitboards
 func (b *Board) UpdateConvenienceBBs() {
-	b.bPieces = b.bKing | b.bQueen | b.bRooks | b.bKnights | b.bPawns
-	b.wPieces = b.wKing | b.wQueen | b.wRooks | b.wKnights | b.wPawns
+	b.wPieces, b.bPieces = b.occupancies()
 	b.emptySqs = ^(b.bPieces | b.wPieces)
 }
 
+// occupancies returns the squares occupied by White's and by Black's pieces, computed from the piece bitboards
+// without changing the board.
+func (b *Board) occupancies() (white, black BitBoard) {
+	white = b.wKing | b.wQueen | b.wRooks | b.wBishops | b.wKnights | b.wPawns
+	black = b.bKing | b.bQueen | b.bRooks | b.bBishops | b.bKnights | b.bPawns
+	return white, black
+}
+
 // Move simply puts a move on the board - does not perform legality check (will simply make the move if an illegal move is entered).
 func (b *Board) Move(source, destination Square, promotionPiece Piece) {
 	movePiece := b.Piece(source)
@@ -217,6 +250,19 @@ func (b *Board) Move(source, destination Square, promotionPiece Piece) {
 	} else {
 		b.SetBBForPiece(promotionPiece, b.BitBoardForPiece(promotionPiece).SetSquareOnBB(destination, true))
 	}
+	// keep the occupancy bitboards in step, so that attack checks straight after a move see the moved piece
+	b.UpdateConvenienceBBs()
+}
+
+// Unmove takes back a move made with Move - placedPiece (the moved piece, or the promotion piece) is removed from
+// destination, capturePiece (which may be NoPiece) is put back on destination, and movePiece is put back on source.
+func (b *Board) Unmove(source, destination Square, movePiece, capturePiece, placedPiece Piece) {
+	b.SetBBForPiece(placedPiece, b.BitBoardForPiece(placedPiece).SetSquareOnBB(destination, false))
+	if capturePiece != NoPiece {
+		b.SetBBForPiece(capturePiece, b.BitBoardForPiece(capturePiece).SetSquareOnBB(destination, true))
+	}
+	b.SetBBForPiece(movePiece, b.BitBoardForPiece(movePiece).SetSquareOnBB(source, true))
+	b.UpdateConvenienceBBs()
 }
 
 // Copy returns a pointer to the copy of a board
@@ -227,11 +273,13 @@ func (b *Board) Copy() *Board {
 		wRooks:   b.wRooks,
 		wQueen:   b.wQueen,
 		wKing:    b.wKing,
+		wBishops: b.wBishops,
 		bPawns:   b.bPawns,
 		bKnights: b.bKnights,
 		bRooks:   b.bRooks,
 		bQueen:   b.bQueen,
 		bKing:    b.bKing,
+		bBishops: b.bBishops,
 		bPieces:  b.bPieces,
 		wPieces:  b.wPieces,
 		emptySqs: b.emptySqs,
@@ -250,7 +298,6 @@ func (b *Board) MovesVector(sq Square) BitBoard {
 	p := b.Piece(sq)
 	pt := p.PieceType()
 	color := p.Color()
-	sqbb := sq.BitBoard()
 	sqint := int(sq)
 	occupied := ^b.emptySqs
 
@@ -259,11 +306,11 @@ func (b *Board) MovesVector(sq Square) BitBoard {
 	case King:
 		return BBKingMoves[sqint] &^ b.PieceOccupancy(color)
 	case Queen:
-		rookAtk := (CalcLinearAttack(occupied, sq.FileBB(), sqbb) ^ CalcLinearAttack(occupied, sq.RankBB(), sqbb))
-		bishAtk := (CalcLinearAttack(occupied, BBDiagonals[sqint], sqbb) ^ CalcLinearAttack(occupied, BBAntiDiagonals[sqint], sqbb))
-		return (rookAtk ^ bishAtk) &^ b.PieceOccupancy(color)
+		return QueenAttacks(sq, occupied) &^ b.PieceOccupancy(color)
 	case Rook:
-		return (CalcLinearAttack(occupied, sq.FileBB(), sqbb) ^ CalcLinearAttack(occupied, sq.RankBB(), sqbb)) &^ b.PieceOccupancy(color)
+		return RookAttacks(sq, occupied) &^ b.PieceOccupancy(color)
+	case Bishop:
+		return DiagonalAttacks(sq, occupied) &^ b.PieceOccupancy(color)
 	case Knight:
 		return BBKnightMoves[sqint] &^ b.PieceOccupancy(color)
 	case Pawn:
@@ -280,42 +327,47 @@ func (b *Board) MovesVector(sq Square) BitBoard {
 	return BitBoard(0)
 }
 
-// CalcLinearAttack calculates attacks for a sliding piece located at PiecePos, which can legally move to pieceMoves, and is blocked by occupied
+// CalcLinearAttack calculates attacks for a sliding piece located at PiecePos, which can legally move to pieceMoves, and is blocked by occupied.
+// MovesVector uses the faster RookAttacks and QueenAttacks lookup tables instead.
 func CalcLinearAttack(occupied, pieceMoves, piecePos BitBoard) BitBoard {
 	OccupiedInMask := occupied & pieceMoves
 	return ((OccupiedInMask - 2*piecePos) ^ (OccupiedInMask.Reverse() - 2*piecePos.Reverse()).Reverse()) & pieceMoves
 }
 
-// KingSquare returns the square of the king of the color c
+// KingSquare returns the square of the king of the color c. It panics if c has no king - see Validate.
 func (b *Board) KingSquare(c Color) Square {
-	if c == White {
-		for sq := 0; sq < NumSquaresInBoard; sq++ {
-			if Square(sq).BitBoard() == b.wKing {
-				return Square(sq)
-			}
-		}
-	}
-	for sq := 0; sq < NumSquaresInBoard; sq++ {
-		if Square(sq).BitBoard() == b.bKing {
-			return Square(sq)
-		}
+	king := b.BitBoardForPiece(NewPiece(King, c))
+	if king == 0 {
+		panic("No King on board")
 	}
-	panic("No King on board")
+	return Square(bits.TrailingZeros64(uint64(king)))
 }
 
-// SquareAttacked checks whether sq is attacked by the side attacker
+// AttackersTo returns the pieces of either color attacking sq, given the occupied squares. It looks outward from sq
+// rather than at every piece: a knight on a knight's move away, a king next to it, pawns on the squares a pawn of the
+// other color on sq would capture, and the first rook, bishop or queen along each ray.
+// Passing an occupancy other than the board's reveals x-ray attackers, e.g. with a capturing piece removed.
+func (b *Board) AttackersTo(sq Square, occupied BitBoard) BitBoard {
+	rookLike := b.wRooks | b.bRooks | b.wQueen | b.bQueen
+	bishopLike := b.wBishops | b.bBishops | b.wQueen | b.bQueen
+	return (BBKnightMoves[sq] & (b.wKnights | b.bKnights)) |
+		(BBKingMoves[sq] & (b.wKing | b.bKing)) |
+		(BBBlackPawnCaptures[sq] & b.wPawns) |
+		(BBWhitePawnCaptures[sq] & b.bPawns) |
+		(RookAttacks(sq, occupied) & rookLike) |
+		(DiagonalAttacks(sq, occupied) & bishopLike)
+}
+
+// SquareAttacked checks whether sq is attacked by the side attacker. Pawn pushes are not attacks, while a piece of
+// the attacker's own color on sq counts as attacked (defended). The board is only read, so it is safe to call on a
+// board shared between goroutines.
 func (b *Board) SquareAttacked(attackSq Square, attacker Color) bool {
-	attackVector := BitBoard(0)
-	pieces := b.PieceOccupancy(attacker)
-	for sq := 0; sq < numSquaresInBoard; sq++ {
-		if pieces.Occupied(Square(sq)) {
-			attackVector = attackVector | b.MovesVector(Square(sq))
-		}
-	}
-	if attackVector.Occupied(attackSq) {
-		return true
+	white, black := b.occupancies()
+	attackers := black
+	if attacker == White {
+		attackers = white
 	}
-	return false
+	return b.AttackersTo(attackSq, white|black)&attackers != 0
 }
 
 // AttackedSquares checks whether a square is attacked by the side attacker
@@ -336,29 +388,17 @@ func (b *Board) InCheck(c Color) bool {
 	return b.SquareAttacked(KingSq, c.Other())
 }
 
-// NumAttacksPerSquare returns a map of Squares to the number of attacks the attacker has to that square
+// NumAttacksPerSquare returns a map of Squares to the number of attacks the attacker has to that square.
+// Squares which are not attacked are not included.
 func (b *Board) NumAttacksPerSquare(attacker Color) map[Square]int8 {
-	var maplist []map[Square]bool
-	for _, pt := range AllPieceTypes {
-		bbp := b.BitBoardForPiece(NewPiece(pt, attacker)).Mapping()
-		for sq := 0; sq < NumSquaresInBoard; sq++ {
-			if bbp[Square(sq)] {
-				maplist = append(maplist, b.MovesVector(Square(sq)).Mapping())
-			}
-		}
-	}
-	return countMaps(maplist)
-}
-
-func countMaps(maps []map[Square]bool) map[Square]int8 {
-	var outputmap map[Square]int8
-	for _, m := range maps {
-		for sq, occ := range m {
-			if occ {
-				outputmap[sq]++
-			}
+	b.UpdateConvenienceBBs()
+	occupied := b.wPieces | b.bPieces
+	pieces := b.PieceOccupancy(attacker)
+	counts := map[Square]int8{}
+	for sq := 0; sq < numSquaresInBoard; sq++ {
+		if n := bits.OnesCount64(uint64(b.AttackersTo(Square(sq), occupied) & pieces)); n > 0 {
+			counts[Square(sq)] = int8(n)
 		}
-
 	}
-	return outputmap
+	return counts
 }
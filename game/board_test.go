@@ -0,0 +1,29 @@
+package game
+
+import "testing"
+
+// TestSquareAttackedReadOnly checks that SquareAttacked answers from the piece bitboards, even when the convenience
+// bitboards are out of date, and leaves the board unchanged.
+func TestSquareAttackedReadOnly(t *testing.T) {
+	pos := NewGamePosition()
+	b := pos.Bd
+	var queenSq Square
+	for sq := Square(0); sq < numSquaresInBoard; sq++ {
+		if b.Piece(sq) == NewPiece(Queen, White) {
+			queenSq = sq
+		}
+	}
+	// lift White's pawns off the board behind the convenience bitboards' back, opening the queen's lines
+	b.SetBBForPiece(WhitePawn, 0)
+	before := *b
+
+	for sq := Square(0); sq < numSquaresInBoard; sq++ {
+		want := QueenAttacks(queenSq, b.wKing|b.wQueen|b.wRooks|b.wBishops|b.wKnights|b.bPieces).Occupied(sq)
+		if want && !b.SquareAttacked(sq, White) {
+			t.Errorf("%s is attacked by the queen on %s, but SquareAttacked says not", sq, queenSq)
+		}
+	}
+	if *b != before {
+		t.Error("SquareAttacked changed the board")
+	}
+}
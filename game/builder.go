@@ -0,0 +1,180 @@
+package game
+
+import "fmt"
+
+// PositionBuilder sets up a Position step by step - e.g. for a puzzle or an odds game - and validates it when built:
+//
+//	pos, err := NewPositionBuilder().
+//		Place(WhiteKing, D1).Place(WhiteRook, A1, F1).Place(BlackKing, D6).
+//		SideToMove(Black).
+//		Build()
+//
+// Steps which cannot be carried out (e.g. placing a piece off the board) are reported by Build, along with any
+// problem found by Position.Validate.
+type PositionBuilder struct {
+	pieces        map[Square]Piece
+	turn          Color
+	moveNumber    uint
+	halfMoveClock uint
+	history       []uint64
+	variant       *Variant
+	err           error
+}
+
+// NewPositionBuilder returns a builder for a position with an empty board and White to move.
+func NewPositionBuilder() *PositionBuilder {
+	return &PositionBuilder{pieces: map[Square]Piece{}, turn: White}
+}
+
+// Edit returns a builder starting from the position, which is not changed.
+func (pos *Position) Edit() *PositionBuilder {
+	pb := NewPositionBuilder()
+	for sq := Square(0); sq < numSquaresInBoard; sq++ {
+		if p := pos.Bd.Piece(sq); p != NoPiece {
+			pb.pieces[sq] = p
+		}
+	}
+	pb.turn = pos.Turn
+	pb.moveNumber = pos.MoveNumber
+	pb.halfMoveClock = pos.HalfMoveClock
+	pb.history = append([]uint64{}, pos.HashList...)
+	pb.variant = pos.Variant
+	return pb
+}
+
+// fail records the first step which could not be carried out.
+func (pb *PositionBuilder) fail(format string, a ...interface{}) {
+	if pb.err == nil {
+		pb.err = fmt.Errorf("builder: "+format, a...)
+	}
+}
+
+// Place puts p on each of the squares, replacing any piece there. Placing NoPiece removes the pieces.
+func (pb *PositionBuilder) Place(p Piece, sqs ...Square) *PositionBuilder {
+	for _, sq := range sqs {
+		if sq < 0 || sq >= numSquaresInBoard {
+			pb.fail("square %d is outside the board", sq)
+			continue
+		}
+		if p == NoPiece {
+			delete(pb.pieces, sq)
+		} else {
+			pb.pieces[sq] = p
+		}
+	}
+	return pb
+}
+
+// Remove removes any pieces on the squares.
+func (pb *PositionBuilder) Remove(sqs ...Square) *PositionBuilder {
+	return pb.Place(NoPiece, sqs...)
+}
+
+// Clear removes every piece from the board.
+func (pb *PositionBuilder) Clear() *PositionBuilder {
+	pb.pieces = map[Square]Piece{}
+	return pb
+}
+
+// SideToMove sets the side to move.
+func (pb *PositionBuilder) SideToMove(c Color) *PositionBuilder {
+	if c != White && c != Black {
+		pb.fail("invalid side to move %s", c)
+		return pb
+	}
+	pb.turn = c
+	return pb
+}
+
+// Clocks sets the number of full moves played (from zero, as Position.MoveNumber) and the number of half moves since
+// the last capture or pawn move.
+func (pb *PositionBuilder) Clocks(moveNumber, halfMoveClock uint) *PositionBuilder {
+	pb.moveNumber = moveNumber
+	pb.halfMoveClock = halfMoveClock
+	return pb
+}
+
+// History sets the Zobrist hashes of the positions reached before this one, oldest first, used to detect repetitions.
+func (pb *PositionBuilder) History(hashes ...uint64) *PositionBuilder {
+	pb.history = append([]uint64{}, hashes...)
+	return pb
+}
+
+// Variant sets the rules the position is played under - nil means LosAlamos.
+func (pb *PositionBuilder) Variant(v *Variant) *PositionBuilder {
+	pb.variant = v
+	return pb
+}
+
+// GiveOdds removes the pieces of the odds from giver's side - for each piece type, the piece of that type nearest
+// giver's back rank, then nearest the a file.
+func (pb *PositionBuilder) GiveOdds(giver Color, odds Odds) *PositionBuilder {
+	for _, pt := range odds.Remove {
+		p := NewPiece(pt, giver)
+		found := false
+		for i := 0; i < numSquaresInBoard && !found; i++ {
+			r, f := Rank(i/numSquaresInRow), File(i%numSquaresInRow)
+			if giver == Black {
+				r = lastRank - r
+			}
+			if sq := GetSquare(f, r); pb.pieces[sq] == p {
+				delete(pb.pieces, sq)
+				found = true
+			}
+		}
+		if !found {
+			pb.fail("no %s to remove for %s odds", p, odds.Name)
+		}
+	}
+	return pb
+}
+
+// Build returns the position, or the first step which could not be carried out, or the ValidationErrors from Validate.
+func (pb *PositionBuilder) Build() (*Position, error) {
+	if pb.err != nil {
+		return nil, pb.err
+	}
+	history := append([]uint64{}, pb.history...)
+	pos, err := newValidPosition(BoardFromMap(pb.pieces), pb.turn, pb.moveNumber, pb.halfMoveClock, history, pb.variant)
+	if err != nil {
+		return nil, fmt.Errorf("builder: %w", err)
+	}
+	return pos, nil
+}
+
+//--------------------------------------------------------------------------------
+
+// Odds is a handicap, where the stronger player starts without some of their pieces.
+type Odds struct {
+	// Name describes the odds, e.g. "queen"
+	Name string
+
+	// Remove are the types of the pieces removed, one piece per entry
+	Remove []PieceType
+}
+
+var (
+	// PawnOdds removes a pawn - the one on the a file in the starting position
+	PawnOdds = Odds{Name: "pawn", Remove: []PieceType{Pawn}}
+
+	// KnightOdds removes a knight - the one nearest the a file
+	KnightOdds = Odds{Name: "knight", Remove: []PieceType{Knight}}
+
+	// RookOdds removes a rook - the one nearest the a file
+	RookOdds = Odds{Name: "rook", Remove: []PieceType{Rook}}
+
+	// QueenOdds removes the queen
+	QueenOdds = Odds{Name: "queen", Remove: []PieceType{Queen}}
+
+	// RookAndKnightOdds removes a rook and a knight
+	RookAndKnightOdds = Odds{Name: "rook and knight", Remove: []PieceType{Rook, Knight}}
+)
+
+// NewOddsGamePosition returns a position at the start of a game in which giver plays without the pieces of the odds.
+func NewOddsGamePosition(giver Color, odds Odds) *Position {
+	pos, err := NewGamePosition().Edit().GiveOdds(giver, odds).Build()
+	if err != nil {
+		panic("Invalid odds game: " + err.Error())
+	}
+	return pos
+}
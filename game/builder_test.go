@@ -0,0 +1,125 @@
+package game
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// TestPositionBuilderErrors checks that Build reports the first step which could not be carried out, and wraps the
+// ValidationErrors of a position which could not arise in a game.
+func TestPositionBuilderErrors(t *testing.T) {
+	kings := func() *PositionBuilder {
+		return NewPositionBuilder().Place(WhiteKing, GetSquare(FileE, Rank1)).Place(BlackKing, GetSquare(FileE, lastRank))
+	}
+	tests := []struct {
+		name string
+		pb   *PositionBuilder
+		want string
+	}{
+		{"square past the board", kings().Place(WhiteRook, Square(numSquaresInBoard)), "outside the board"},
+		{"negative square", kings().Remove(Square(-1)), "outside the board"},
+		{"no side to move", kings().SideToMove(NoColor), "invalid side to move"},
+		{"first failed step", kings().SideToMove(NoColor).Place(WhiteRook, Square(-1)), "invalid side to move"},
+		{"odds without the piece", kings().GiveOdds(White, QueenOdds), "to remove for queen odds"},
+	}
+	for _, test := range tests {
+		pos, err := test.pb.Build()
+		if err == nil || !strings.HasPrefix(err.Error(), "builder: ") || !strings.Contains(err.Error(), test.want) {
+			t.Errorf("%s: Build() = %v, %v, want an error containing %q", test.name, pos, err, test.want)
+		}
+	}
+
+	_, err := NewPositionBuilder().Place(WhiteKing, GetSquare(FileE, Rank1)).Build()
+	var ve ValidationErrors
+	if !errors.As(err, &ve) || !ve.Has(MissingKing) || !strings.HasPrefix(err.Error(), "builder: ") {
+		t.Errorf("Build() without a black king = %v, want wrapped ValidationErrors with %s", err, MissingKing)
+	}
+}
+
+// TestPositionEdit checks that a builder from Edit builds the same position, and that building a changed one leaves
+// the original untouched.
+func TestPositionEdit(t *testing.T) {
+	pos := NewGamePosition()
+	for i := 0; i < 4; i++ {
+		pos.UnsafeMove(pos.GenerateLegalMoves()[0])
+	}
+	before := pos.Copy()
+	same := func(a, b *Position) bool {
+		if a.FEN() != b.FEN() || a.Hash != b.Hash || a.InCheck != b.InCheck || a.Rules() != b.Rules() ||
+			len(a.HashList) != len(b.HashList) {
+			return false
+		}
+		for i := range a.HashList {
+			if a.HashList[i] != b.HashList[i] {
+				return false
+			}
+		}
+		return true
+	}
+
+	copied, err := pos.Edit().Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !same(copied, pos) {
+		t.Errorf("Edit().Build() = %s, want %s", copied.FEN(), pos.FEN())
+	}
+
+	edited, err := pos.Edit().
+		Clear().Place(WhiteKing, GetSquare(FileA, Rank1)).Place(BlackKing, GetSquare(FileE, lastRank)).
+		SideToMove(pos.Turn.Other()).Clocks(40, 7).History(1, 2, 3).Variant(LosAlamosStalemateLoss).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	edited.UnsafeMove(edited.GenerateLegalMoves()[0])
+	copied.UnsafeMove(copied.GenerateLegalMoves()[0])
+	if !same(pos, before) {
+		t.Errorf("after playing on from edited copies, the position is %s, want %s", pos.FEN(), before.FEN())
+	}
+}
+
+// TestOddsGamePosition checks that each odds removes the documented pieces - the a file pawn, or the piece nearest the
+// a file on the back rank - from the giver's side of the starting position, and nothing else.
+func TestOddsGamePosition(t *testing.T) {
+	start := NewGamePosition()
+	for _, odds := range []Odds{PawnOdds, KnightOdds, RookOdds, QueenOdds, RookAndKnightOdds} {
+		for _, giver := range []Color{White, Black} {
+			backRank, pawnRank := Rank1, Rank2
+			if giver == Black {
+				backRank, pawnRank = lastRank, lastRank-1
+			}
+			want := map[Square]bool{}
+			for _, pt := range odds.Remove {
+				if pt == Pawn {
+					want[GetSquare(FileA, pawnRank)] = true
+					continue
+				}
+				for f := FileA; f < numSquaresInRow; f++ {
+					if sq := GetSquare(f, backRank); start.Bd.Piece(sq) == NewPiece(pt, giver) {
+						want[sq] = true
+						break
+					}
+				}
+			}
+			if len(want) != len(odds.Remove) {
+				t.Fatalf("%s odds: the starting position %s has no piece to remove", odds.Name, start.FEN())
+			}
+
+			pos := NewOddsGamePosition(giver, odds)
+			for sq := Square(0); sq < numSquaresInBoard; sq++ {
+				wantPiece := start.Bd.Piece(sq)
+				if want[sq] {
+					wantPiece = NoPiece
+				}
+				if got := pos.Bd.Piece(sq); got != wantPiece {
+					t.Errorf("%s odds given by %s: %s has %s, want %s", odds.Name, giver, sq, got, wantPiece)
+				}
+			}
+			if pos.Turn != White || pos.MoveNumber != 0 || pos.HalfMoveClock != 0 {
+				t.Errorf("%s odds given by %s: %s is not at the start of a game", odds.Name, giver, pos.FEN())
+			}
+		}
+	}
+}
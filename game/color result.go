@@ -109,3 +109,54 @@ func NewResultWin(c Color) Result {
 	}
 	return Draw
 }
+
+//--------------------------------------------------------------------------------
+
+// Termination is the reason a game ended, alongside its Result
+type Termination int8
+
+// Enumerating all possible Terminations
+const (
+	// NoTermination is the Termination of a game in play
+	NoTermination Termination = iota
+	Checkmate
+	Stalemate
+	ThreefoldRepetition
+	InsufficientMaterial
+	// MoveLimit - the variant's NoProgressLimit half moves without a capture or pawn move
+	MoveLimit
+	// IllegalMove - the game was forfeited by playing an illegal move
+	IllegalMove
+	Timeout
+	Resignation
+	// Adjudication - the game was stopped and its result decided from the position (e.g. by an arbiter or a match
+	// runner)
+	Adjudication
+	AgreedDraw
+)
+
+func (t Termination) String() string {
+	switch t {
+	case Checkmate:
+		return "Checkmate"
+	case Stalemate:
+		return "Stalemate"
+	case ThreefoldRepetition:
+		return "Threefold Repetition"
+	case InsufficientMaterial:
+		return "Insufficient Material"
+	case MoveLimit:
+		return "Move Limit"
+	case IllegalMove:
+		return "Illegal Move"
+	case Timeout:
+		return "Timeout"
+	case Resignation:
+		return "Resignation"
+	case Adjudication:
+		return "Adjudication"
+	case AgreedDraw:
+		return "Agreed Draw"
+	}
+	return "Not Terminated"
+}
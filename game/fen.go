@@ -0,0 +1,178 @@
+package game
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// The FEN-style notation used here is standard FEN adapted to the 6x6 board, e.g. the starting position is
+// 	rnqknr/pppppp/6/6/PPPPPP/RNQKNR w - - 0 1
+// Ranks are listed from the 6th (last) down to the 1st, each from the a file to the f (last) file. Los Alamos chess
+// has no castling or en passant, so those two fields are always "-" and may be left out when parsing.
+
+var (
+	// fenPieceChars is a mapping from Pieces to their FEN characters.
+	fenPieceChars = map[Piece]byte{
+		WhitePawn:   'P',
+		WhiteKnight: 'N',
+		WhiteRook:   'R',
+		WhiteQueen:  'Q',
+		WhiteKing:   'K',
+		WhiteBishop: 'B',
+
+		BlackPawn:   'p',
+		BlackKnight: 'n',
+		BlackRook:   'r',
+		BlackQueen:  'q',
+		BlackKing:   'k',
+		BlackBishop: 'b',
+	}
+
+	// fenCharPieces is a mapping from FEN characters to Pieces.
+	fenCharPieces = map[byte]Piece{
+		'P': WhitePawn,
+		'N': WhiteKnight,
+		'R': WhiteRook,
+		'Q': WhiteQueen,
+		'K': WhiteKing,
+		'B': WhiteBishop,
+
+		'p': BlackPawn,
+		'n': BlackKnight,
+		'r': BlackRook,
+		'q': BlackQueen,
+		'k': BlackKing,
+		'b': BlackBishop,
+	}
+)
+
+// FEN returns the piece placement field of the FEN-style notation for the board - e.g. "rnqknr/pppppp/6/6/PPPPPP/RNQKNR".
+func (b *Board) FEN() string {
+	var sb strings.Builder
+	for r := lastRank; r >= Rank1; r-- {
+		empty := 0
+		for f := FileA; f < numSquaresInRow; f++ {
+			p := b.Piece(GetSquare(f, r))
+			if p == NoPiece {
+				empty++
+				continue
+			}
+			if empty > 0 {
+				sb.WriteString(strconv.Itoa(empty))
+				empty = 0
+			}
+			sb.WriteByte(fenPieceChars[p])
+		}
+		if empty > 0 {
+			sb.WriteString(strconv.Itoa(empty))
+		}
+		if r > Rank1 {
+			sb.WriteByte('/')
+		}
+	}
+	return sb.String()
+}
+
+// FEN returns the FEN-style notation for the position.
+// MoveNumber counts completed full moves from zero, so it is written as the FEN full move number minus one.
+func (pos *Position) FEN() string {
+	side := "w"
+	if pos.Turn == Black {
+		side = "b"
+	}
+	return fmt.Sprintf("%s %s - - %d %d", pos.Bd.FEN(), side, pos.HalfMoveClock, pos.MoveNumber+1)
+}
+
+// BoardFromFEN parses the piece placement field of the FEN-style notation into a Board.
+func BoardFromFEN(placement string) (*Board, error) {
+	rows := strings.Split(placement, "/")
+	if len(rows) != NumSquaresInRow {
+		return nil, fmt.Errorf("fen: expected %d rows in piece placement %q, found %d", NumSquaresInRow, placement, len(rows))
+	}
+
+	m := map[Square]Piece{}
+	for i, row := range rows {
+		r := lastRank - Rank(i)
+		f := 0
+		for j := 0; j < len(row); j++ {
+			c := row[j]
+			if c >= '1' && c <= '0'+numSquaresInRow {
+				f += int(c - '0')
+				continue
+			}
+			p, ok := fenCharPieces[c]
+			if !ok {
+				return nil, fmt.Errorf("fen: invalid piece letter %q in row %q (rank %s)", c, row, r)
+			}
+			if f < NumSquaresInRow {
+				m[GetSquare(File(f), r)] = p
+			}
+			f++
+		}
+		if f != NumSquaresInRow {
+			return nil, fmt.Errorf("fen: row %q (rank %s) describes %d squares, expected %d", row, r, f, NumSquaresInRow)
+		}
+	}
+
+	bd := BoardFromMap(m)
+	if err := bd.Validate(); err != nil {
+		return nil, fmt.Errorf("fen: %w", err)
+	}
+	return bd, nil
+}
+
+// PositionFromFEN parses a FEN-style string into a Position.
+// The castling and en passant fields, and both move clocks, may be left out - so four fields are either the two "-"
+// fields without the clocks, or the clocks without the "-" fields.
+func PositionFromFEN(fen string) (*Position, error) {
+	fields := strings.Fields(fen)
+	switch len(fields) {
+	case 2:
+	case 4:
+		if fields[2] == "-" && fields[3] == "-" {
+			fields = fields[:2]
+		}
+	case 6:
+		// drop the castling and en passant fields, which are always empty in Los Alamos chess
+		if fields[2] != "-" || fields[3] != "-" {
+			return nil, fmt.Errorf("fen: castling and en passant fields must be \"-\" in %q", fen)
+		}
+		fields = append(fields[:2], fields[4:]...)
+	default:
+		return nil, fmt.Errorf("fen: expected 2, 4 or 6 fields in %q, found %d", fen, len(fields))
+	}
+
+	bd, err := BoardFromFEN(fields[0])
+	if err != nil {
+		return nil, err
+	}
+
+	var turn Color
+	switch fields[1] {
+	case "w":
+		turn = White
+	case "b":
+		turn = Black
+	default:
+		return nil, fmt.Errorf("fen: invalid side to move %q, expected \"w\" or \"b\"", fields[1])
+	}
+
+	var halfMoveClock, fullMoveNumber uint64 = 0, 1
+	if len(fields) == 4 {
+		halfMoveClock, err = strconv.ParseUint(fields[2], 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("fen: invalid half move clock %q", fields[2])
+		}
+		fullMoveNumber, err = strconv.ParseUint(fields[3], 10, 32)
+		if err != nil || fullMoveNumber == 0 {
+			return nil, fmt.Errorf("fen: invalid full move number %q", fields[3])
+		}
+	}
+
+	pos, err := NewValidPosition(bd, turn, uint(fullMoveNumber-1), uint(halfMoveClock), []uint64{})
+	if err != nil {
+		return nil, fmt.Errorf("fen: %w", err)
+	}
+	return pos, nil
+}
@@ -0,0 +1,111 @@
+package game
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// kingsPlacement returns the piece placement of a board with only the two kings, the black one on the first square
+// of the last rank and the white one on a1, with the rows given replacing the empty ranks in between.
+func kingsPlacement(middle ...string) string {
+	rows := []string{"k" + strconv.Itoa(NumSquaresInRow-1)}
+	for i := 0; i < NumSquaresInRow-2; i++ {
+		row := strconv.Itoa(NumSquaresInRow)
+		if i < len(middle) {
+			row = middle[i]
+		}
+		rows = append(rows, row)
+	}
+	return strings.Join(append(rows, "K"+strconv.Itoa(NumSquaresInRow-1)), "/")
+}
+
+// TestFENRoundTrip checks that positions are written as they were read, and that the castling and en passant fields
+// and the clocks may each be left out.
+func TestFENRoundTrip(t *testing.T) {
+	fens := []string{StartingFEN, kingsPlacement() + " b - - 7 31"}
+	for _, pr := range PerftResults {
+		fens = append(fens, pr.FEN)
+	}
+	for _, fen := range fens {
+		pos, err := PositionFromFEN(fen)
+		if err != nil {
+			t.Fatalf("PositionFromFEN(%q): %v", fen, err)
+		}
+		again, err := PositionFromFEN(pos.FEN())
+		if err != nil {
+			t.Fatalf("PositionFromFEN(%q): %v", pos.FEN(), err)
+		}
+		if again.FEN() != pos.FEN() || again.Hash != pos.Hash {
+			t.Errorf("%q read back as %q", pos.FEN(), again.FEN())
+		}
+	}
+
+	placement := kingsPlacement()
+	tests := []struct {
+		fen  string
+		want string
+	}{
+		{placement + " w", placement + " w - - 0 1"},
+		{placement + " b - -", placement + " b - - 0 1"},
+		{placement + " b 3 12", placement + " b - - 3 12"},
+		{placement + " w - - 3 12", placement + " w - - 3 12"},
+	}
+	for _, test := range tests {
+		pos, err := PositionFromFEN(test.fen)
+		if err != nil {
+			t.Errorf("PositionFromFEN(%q): %v", test.fen, err)
+			continue
+		}
+		if got := pos.FEN(); got != test.want {
+			t.Errorf("PositionFromFEN(%q).FEN() = %q, want %q", test.fen, got, test.want)
+		}
+	}
+}
+
+// TestFENErrors checks that each kind of malformed FEN is rejected, with an error saying what is wrong.
+func TestFENErrors(t *testing.T) {
+	placement := kingsPlacement()
+	empty := strconv.Itoa(NumSquaresInRow)
+	noBlackKing := empty + placement[strings.Index(placement, "/"):]
+	tests := []struct {
+		name string
+		fen  string
+		want string
+	}{
+		{"one field", placement, "expected 2, 4 or 6 fields"},
+		{"three fields", placement + " w -", "expected 2, 4 or 6 fields"},
+		{"five fields", placement + " w - - 0", "expected 2, 4 or 6 fields"},
+		{"castling rights", placement + " w KQ - 0 1", "castling and en passant"},
+		{"en passant square", placement + " w - a3 0 1", "castling and en passant"},
+		{"too few ranks", placement[strings.Index(placement, "/")+1:] + " w", "rows in piece placement"},
+		{"too many ranks", empty + "/" + placement + " w", "rows in piece placement"},
+		{"bad piece letter", kingsPlacement("x"+strconv.Itoa(NumSquaresInRow-1)) + " w", "invalid piece letter"},
+		{"overlong rank", kingsPlacement("p"+empty) + " w", "describes"},
+		{"short rank", kingsPlacement(strconv.Itoa(NumSquaresInRow-1)) + " w", "describes"},
+		{"bad side to move", placement + " white", "invalid side to move"},
+		{"bad half move clock", placement + " w - - x 1", "invalid half move clock"},
+		{"negative half move clock", placement + " w -1 1", "invalid half move clock"},
+		{"bad full move number", placement + " w - - 0 y", "invalid full move number"},
+		{"zero full move number", placement + " w 0 0", "invalid full move number"},
+		{"invalid board", noBlackKing + " w", "missing king"},
+	}
+	for _, test := range tests {
+		_, err := PositionFromFEN(test.fen)
+		if err == nil {
+			t.Errorf("%s: PositionFromFEN(%q) succeeded", test.name, test.fen)
+			continue
+		}
+		if !strings.Contains(err.Error(), test.want) {
+			t.Errorf("%s: PositionFromFEN(%q) = %q, want an error containing %q", test.name, test.fen, err, test.want)
+		}
+	}
+
+	// an impossible board is reported as ValidationErrors
+	_, err := PositionFromFEN(noBlackKing + " w")
+	var ve ValidationErrors
+	if !errors.As(err, &ve) || !ve.Has(MissingKing) {
+		t.Errorf("PositionFromFEN of a board without a black king = %v, want ValidationErrors with MissingKing", err)
+	}
+}
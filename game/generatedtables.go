@@ -0,0 +1,25 @@
+//go:build gardner || board8x8
+// +build gardner board8x8
+
+package game
+
+// The move and Zobrist tables for boards other than 6x6, generated at startup (see geometry.go).
+// The i'th element of the move arrays is the bitboard for the moves available for a piece located at Square(i).
+var (
+	BBRookMoves   = rayTable(rookDirections)
+	BBKingMoves   = stepTable(kingSteps)
+	BBKnightMoves = stepTable(knightSteps)
+	BBQueenMoves  = rayTable(append(append([][2]int{}, rookDirections...), diagonalDirections...))
+
+	BBWhitePawnPushes   = stepTable([][2]int{{0, 1}})
+	BBWhitePawnCaptures = stepTable([][2]int{{-1, 1}, {1, 1}})
+	BBBlackPawnPushes   = stepTable([][2]int{{0, -1}})
+	BBBlackPawnCaptures = stepTable([][2]int{{-1, -1}, {1, -1}})
+
+	BBDiagonals     = rayTable([][2]int{{1, 1}, {-1, -1}})
+	BBAntiDiagonals = rayTable([][2]int{{-1, 1}, {1, -1}})
+)
+
+// ZobristKeys holds the random numbers used to calculate the Zobrist hash of a position, indexed by [Square][Piece].
+// Empty squares contribute the NoPiece key.
+var ZobristKeys, whiteHash, blackHash = zobristTable()
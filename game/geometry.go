@@ -0,0 +1,61 @@
+package game
+
+// The board size is chosen when building, so that it is a constant and the 6x6 Los Alamos board is as fast as if it
+// were the only one:
+//
+// 	go build ./...                  6x6 Los Alamos chess (geometry6x6.go)
+// 	go build -tags gardner ./...    5x5 Gardner minichess (geometry5x5.go)
+// 	go build -tags board8x8 ./...   8x8 chess without bishops (geometry8x8.go)
+//
+// Each geometry file defines numSquaresInRow, the Square constants, the standard variant played on the board and known
+// perft counts for it. The move and Zobrist tables are literals for the 6x6 board (attackvectors.go, zobristkeys.go)
+// and are generated at startup by the functions below for the others (generatedtables.go).
+// The main package - its board setups and evaluation weights - is written for the 6x6 board only.
+
+const (
+	// numSquaresInBoard is the number of squares on the board - at most 64, so that a BitBoard holds them all
+	numSquaresInBoard = numSquaresInRow * numSquaresInRow
+
+	// lastRank is the rank White's pawns promote on, and Black's back rank
+	lastRank = Rank(numSquaresInRow - 1)
+)
+
+var (
+	kingSteps   = [][2]int{{1, 0}, {-1, 0}, {0, 1}, {0, -1}, {1, 1}, {1, -1}, {-1, 1}, {-1, -1}}
+	knightSteps = [][2]int{{1, 2}, {2, 1}, {-1, 2}, {-2, 1}, {1, -2}, {2, -1}, {-1, -2}, {-2, -1}}
+)
+
+// stepTable returns, for each square, the squares one step away (as {file, rank} offsets) which are on the board.
+func stepTable(steps [][2]int) [numSquaresInBoard]BitBoard {
+	var table [numSquaresInBoard]BitBoard
+	for sq := Square(0); sq < numSquaresInBoard; sq++ {
+		for _, s := range steps {
+			f, r := int(sq.File())+s[0], int(sq.Rank())+s[1]
+			if f >= 0 && f < numSquaresInRow && r >= 0 && r < numSquaresInRow {
+				table[sq] |= GetSquare(File(f), Rank(r)).BitBoard()
+			}
+		}
+	}
+	return table
+}
+
+// rayTable returns, for each square, the squares along the given directions on an empty board.
+func rayTable(directions [][2]int) [numSquaresInBoard]BitBoard {
+	var table [numSquaresInBoard]BitBoard
+	for sq := Square(0); sq < numSquaresInBoard; sq++ {
+		table[sq] = slidingAttacks(sq, 0, directions)
+	}
+	return table
+}
+
+// zobristTable returns random Zobrist keys for every square and piece, and for each side to move.
+func zobristTable() (keys [numSquaresInBoard][numPieces]uint64, white, black uint64) {
+	// fixed seed, so hashes are the same on every run
+	rng := xorshift(2718281828)
+	for sq := range keys {
+		for p := range keys[sq] {
+			keys[sq][p] = rng.next()
+		}
+	}
+	return keys, rng.next(), rng.next()
+}
@@ -0,0 +1,70 @@
+//go:build gardner
+// +build gardner
+
+package game
+
+// The 5x5 board of Gardner minichess, built with the gardner tag (see geometry.go). Each side has a bishop, and there
+// is no pawn double step or castling.
+
+// numSquaresInRow is the number of files and of ranks
+const numSquaresInRow = 5
+
+// Enumerating all Squares on the board
+const (
+	A1 Square = iota
+	B1
+	C1
+	D1
+	E1
+	A2
+	B2
+	C2
+	D2
+	E2
+	A3
+	B3
+	C3
+	D3
+	E3
+	A4
+	B4
+	C4
+	D4
+	E4
+	A5
+	B5
+	C5
+	D5
+	E5
+)
+
+const (
+	// StartingFEN is the FEN-style string for the starting position.
+	StartingFEN = "rnbqk/ppppp/5/PPPPP/RNBQK w - - 0 1"
+
+	// standardVariantName is the name of the LosAlamos variant
+	standardVariantName = "gardner"
+
+	// standardPawnDoubleStep is whether pawns may move two squares in the LosAlamos variant
+	standardPawnDoubleStep = false
+)
+
+var (
+	// PromotionPieceTypes is a slice of the piece types that pawns may promote to
+	PromotionPieceTypes = []PieceType{
+		Knight,
+		Bishop,
+		Rook,
+		Queen,
+	}
+
+	// originalPieceCounts is the number of each type of piece a side starts with
+	originalPieceCounts = map[PieceType]int{Knight: 1, Bishop: 1, Rook: 1, Queen: 1}
+
+	// PerftResults are known-good perft counts for the starting position and a promotion, checked against an independent mailbox move
+	// generator.
+	PerftResults = []PerftResult{
+		{"NewGame", "rnbqk/ppppp/5/PPPPP/RNBQK w", []uint64{7, 53, 506, 4775, 52512}},
+		{"Promotion", "k4/2P2/5/1b3/K4 w", []uint64{3, 18, 133, 695, 5309}},
+	}
+)
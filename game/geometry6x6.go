@@ -0,0 +1,105 @@
+//go:build !gardner && !board8x8
+// +build !gardner,!board8x8
+
+package game
+
+// The 6x6 Los Alamos chess board, built unless the gardner or board8x8 tag is set (see geometry.go).
+
+// numSquaresInRow is the number of files and of ranks
+const numSquaresInRow = 6
+
+// Enumerating all Squares on the board
+const (
+	A1 Square = iota
+	B1
+	C1
+	D1
+	E1
+	F1
+	A2
+	B2
+	C2
+	D2
+	E2
+	F2
+	A3
+	B3
+	C3
+	D3
+	E3
+	F3
+	A4
+	B4
+	C4
+	D4
+	E4
+	F4
+	A5
+	B5
+	C5
+	D5
+	E5
+	F5
+	A6
+	B6
+	C6
+	D6
+	E6
+	F6
+)
+
+// Bitboards for each file
+const (
+	BBFileA BitBoard = 1090785345
+	BBFileB BitBoard = 2181570690
+	BBFileC BitBoard = 4363141380
+	BBFileD BitBoard = 8726282760
+	BBFileE BitBoard = 17452565520
+	BBFileF BitBoard = 34905131040
+)
+
+// Bitboards for each rank
+const (
+	BBRank1 BitBoard = 63
+	BBRank2 BitBoard = 4032
+	BBRank3 BitBoard = 258048
+	BBRank4 BitBoard = 16515072
+	BBRank5 BitBoard = 1056964608
+	BBRank6 BitBoard = 67645734912
+)
+
+const (
+	// StartingFEN is the FEN-style string for the starting position.
+	StartingFEN = "rnqknr/pppppp/6/6/PPPPPP/RNQKNR w - - 0 1"
+
+	// standardVariantName is the name of the LosAlamos variant
+	standardVariantName = "losalamos"
+
+	// standardPawnDoubleStep is whether pawns may move two squares in the LosAlamos variant
+	standardPawnDoubleStep = false
+)
+
+var (
+	// PromotionPieceTypes is a slice of the piece types that pawns may promote to
+	PromotionPieceTypes = []PieceType{
+		Knight,
+		Rook,
+		Queen,
+	}
+
+	// originalPieceCounts is the number of each type of piece a side starts with
+	originalPieceCounts = map[PieceType]int{Knight: 2, Rook: 2, Queen: 1}
+
+	// PerftResults are known-good perft counts for the starting position and the setups in boardsetups.go, checked against
+	// an independent mailbox move generator.
+	PerftResults = []PerftResult{
+		{"NewGame", "rnqknr/pppppp/6/6/PPPPPP/RNQKNR w", []uint64{10, 100, 1212, 14332, 191846, 2549164}},
+		{"PuzzlingPos", "5k/rp1p2/n2pnr/P4P/5R/RN1KN1 w", []uint64{15, 250, 3246, 56152}},
+		{"PuzzlingPos2", "5k/rp1p2/n2pnr/P4P/2R3/RN1KN1 w", []uint64{17, 275, 3797, 66159}},
+		{"InterestingPos", "r1qknr/pppppp/2n3/3P2/PPP1PP/RNQKNR w", []uint64{14, 193, 2684, 36552}},
+		{"CheckPos", "r1qknr/pppp1p/2n1pQ/3P2/PPP1PP/RN1KNR b", []uint64{2, 33, 455, 7099}},
+		{"KNPawnGame", "r2k1r/2pn2/4p1/pPp3/R1P1KP/3R2 b", []uint64{14, 213, 3371, 46194}},
+		{"KNPawnOpening", "rnqknr/ppp1pp/3p2/4P1/PPPPKP/RNQ1NR b", []uint64{15, 175, 2732, 33775}},
+		{"Promotion", "k5/3P2/6/6/6/K5 w", []uint64{6, 15, 157, 665}},
+	}
+)
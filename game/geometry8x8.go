@@ -0,0 +1,108 @@
+//go:build board8x8 && !gardner
+// +build board8x8,!gardner
+
+package game
+
+// The 8x8 board, built with the board8x8 tag (see geometry.go). The game is chess without bishops - as Los Alamos
+// chess is - with the pawn double step but no castling or en passant.
+
+// numSquaresInRow is the number of files and of ranks
+const numSquaresInRow = 8
+
+// Enumerating all Squares on the board
+const (
+	A1 Square = iota
+	B1
+	C1
+	D1
+	E1
+	F1
+	G1
+	H1
+	A2
+	B2
+	C2
+	D2
+	E2
+	F2
+	G2
+	H2
+	A3
+	B3
+	C3
+	D3
+	E3
+	F3
+	G3
+	H3
+	A4
+	B4
+	C4
+	D4
+	E4
+	F4
+	G4
+	H4
+	A5
+	B5
+	C5
+	D5
+	E5
+	F5
+	G5
+	H5
+	A6
+	B6
+	C6
+	D6
+	E6
+	F6
+	G6
+	H6
+	A7
+	B7
+	C7
+	D7
+	E7
+	F7
+	G7
+	H7
+	A8
+	B8
+	C8
+	D8
+	E8
+	F8
+	G8
+	H8
+)
+
+const (
+	// StartingFEN is the FEN-style string for the starting position.
+	StartingFEN = "rn1qk1nr/pppppppp/8/8/8/8/PPPPPPPP/RN1QK1NR w - - 0 1"
+
+	// standardVariantName is the name of the LosAlamos variant
+	standardVariantName = "nobishops8x8"
+
+	// standardPawnDoubleStep is whether pawns may move two squares in the LosAlamos variant
+	standardPawnDoubleStep = true
+)
+
+var (
+	// PromotionPieceTypes is a slice of the piece types that pawns may promote to
+	PromotionPieceTypes = []PieceType{
+		Knight,
+		Rook,
+		Queen,
+	}
+
+	// originalPieceCounts is the number of each type of piece a side starts with
+	originalPieceCounts = map[PieceType]int{Knight: 2, Rook: 2, Queen: 1}
+
+	// PerftResults are known-good perft counts for the starting position and a promotion, checked against an independent mailbox move
+	// generator.
+	PerftResults = []PerftResult{
+		{"NewGame", "rn1qk1nr/pppppppp/8/8/8/8/PPPPPPPP/RN1QK1NR w", []uint64{22, 484, 11204, 258864}},
+		{"Promotion", "k7/3P4/8/8/8/2n5/4p1P1/K7 w", []uint64{6, 55, 435, 4886}},
+	}
+)
@@ -0,0 +1,85 @@
+package game
+
+import "math/bits"
+
+// legalityInfo is what the legal move generator needs to know about a position to emit only legal moves, computed
+// once per position instead of testing every pseudolegal move for check.
+type legalityInfo struct {
+	// checkers are the enemy pieces attacking the king
+	checkers BitBoard
+	// checkMask is the squares pieces other than the king may move to - the checker and the squares between it and the
+	// king when in single check, nothing in double check, every square otherwise
+	checkMask BitBoard
+	// danger is the squares attacked by the enemy with the king removed from the board, which the king may not move to
+	danger BitBoard
+	// pinned are the pieces pinned against the king, and pinRays[sq] the squares the piece on sq may move along
+	pinned  BitBoard
+	pinRays [numSquaresInBoard]BitBoard
+}
+
+// legalityInfo computes checkers, pins and the king's danger squares for the side to move.
+func (pos *Position) legalityInfo(li *legalityInfo) {
+	b := pos.Bd
+	b.UpdateConvenienceBBs()
+	us, them := pos.Turn, pos.Turn.Other()
+	kingBB := b.BitBoardForPiece(NewPiece(King, us))
+	kingSq := Square(bits.TrailingZeros64(uint64(kingBB)))
+	occupied := b.wPieces | b.bPieces
+	own := b.PieceOccupancy(us)
+
+	li.checkers = b.AttackersTo(kingSq, occupied) & b.PieceOccupancy(them)
+	switch bits.OnesCount64(uint64(li.checkers)) {
+	case 0:
+		li.checkMask = BBMask
+	case 1:
+		li.checkMask = li.checkers | Between(kingSq, Square(bits.TrailingZeros64(uint64(li.checkers))))
+	default:
+		li.checkMask = 0
+	}
+
+	li.danger = 0
+	withoutKing := occupied &^ kingBB
+	for pieces := b.PieceOccupancy(them); pieces != 0; pieces &= pieces - 1 {
+		sq := Square(bits.TrailingZeros64(uint64(pieces)))
+		li.danger |= pieceAttacks(b.Piece(sq), sq, withoutKing)
+	}
+
+	// a piece is pinned if it is the only piece between the king and an enemy slider on the same line
+	li.pinned = 0
+	queens := b.BitBoardForPiece(NewPiece(Queen, them))
+	rookSliders := (b.BitBoardForPiece(NewPiece(Rook, them)) | queens) & RookAttacks(kingSq, 0)
+	diagonalSliders := (b.BitBoardForPiece(NewPiece(Bishop, them)) | queens) & DiagonalAttacks(kingSq, 0)
+	for sliders := rookSliders | diagonalSliders; sliders != 0; sliders &= sliders - 1 {
+		sliderSq := Square(bits.TrailingZeros64(uint64(sliders)))
+		between := Between(kingSq, sliderSq) & occupied
+		if bits.OnesCount64(uint64(between)) == 1 && between&own != 0 {
+			pinnedSq := Square(bits.TrailingZeros64(uint64(between)))
+			li.pinned |= between
+			li.pinRays[pinnedSq] = Between(kingSq, sliderSq) | sliderSq.BitBoard()
+		}
+	}
+}
+
+// pieceAttacks returns the squares attacked by piece p on sq, given the occupied squares. Unlike MovesVector, squares
+// holding pieces of the same color are included (they are defended), and pawn pushes are not.
+func pieceAttacks(p Piece, sq Square, occupied BitBoard) BitBoard {
+	switch p {
+	case WhitePawn:
+		return BBWhitePawnCaptures[sq]
+	case BlackPawn:
+		return BBBlackPawnCaptures[sq]
+	}
+	switch p.PieceType() {
+	case Knight:
+		return BBKnightMoves[sq]
+	case Rook:
+		return RookAttacks(sq, occupied)
+	case Bishop:
+		return DiagonalAttacks(sq, occupied)
+	case Queen:
+		return QueenAttacks(sq, occupied)
+	case King:
+		return BBKingMoves[sq]
+	}
+	return 0
+}
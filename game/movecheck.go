@@ -0,0 +1,111 @@
+package game
+
+import "fmt"
+
+// IllegalMoveReason is why CheckMove rejected a ply.
+type IllegalMoveReason uint8
+
+const (
+	// WrongSide - the ply is not made by the side to move
+	WrongSide IllegalMoveReason = iota + 1
+	// NoOwnPiece - the source square does not hold a piece of the side to move
+	NoOwnPiece
+	// MissingCapture - the destination square is occupied but the ply is not marked as a capture
+	MissingCapture
+	// NothingToCapture - the ply is marked as a capture but the destination square is empty
+	NothingToCapture
+	// UnreachableSquare - the piece on the source square cannot move to the destination square
+	UnreachableSquare
+	// MissingPromotion - a pawn reaching the 1st or last rank does not say what it promotes to
+	MissingPromotion
+	// InvalidPromotion - the ply promotes to a piece type the variant does not allow, or is not a pawn reaching the
+	// 1st or last rank
+	InvalidPromotion
+	// LeavesKingInCheck - the side to move's king would be attacked after the ply
+	LeavesKingInCheck
+)
+
+// String returns a description of the reason.
+// Implements the fmt.Stringer interface.
+func (r IllegalMoveReason) String() string {
+	switch r {
+	case WrongSide:
+		return "not the side to move"
+	case NoOwnPiece:
+		return "no piece of the side to move on the source square"
+	case MissingCapture:
+		return "destination square is occupied but the move is not a capture"
+	case NothingToCapture:
+		return "move is a capture but the destination square is empty"
+	case UnreachableSquare:
+		return "piece cannot move to the destination square"
+	case MissingPromotion:
+		return "pawn reaching the last rank must promote"
+	case InvalidPromotion:
+		return "invalid promotion"
+	case LeavesKingInCheck:
+		return "king would be in check"
+	}
+	return "unknown reason"
+}
+
+// IllegalMoveError is returned by CheckMove and ApplyMove for an illegal ply.
+type IllegalMoveError struct {
+	Ply    Ply
+	Reason IllegalMoveReason
+}
+
+// Error implements the error interface.
+func (e *IllegalMoveError) Error() string {
+	return fmt.Sprintf("illegal move %s: %s", e.Ply.String(), e.Reason)
+}
+
+// CheckMove returns an *IllegalMoveError saying why p is illegal in the position, or nil if it is legal.
+func (pos *Position) CheckMove(p *Ply) error {
+	illegal := func(r IllegalMoveReason) error {
+		return &IllegalMoveError{Ply: *p, Reason: r}
+	}
+	pos.Bd.UpdateConvenienceBBs()
+
+	if p.Side != pos.Turn {
+		return illegal(WrongSide)
+	}
+	if !pos.Bd.PieceOccupancy(p.Side).Occupied(p.SourceSq) {
+		return illegal(NoOwnPiece)
+	}
+	occupied := pos.Bd.Piece(p.DestinationSq) != NoPiece
+	if occupied && !p.Capture {
+		return illegal(MissingCapture)
+	}
+	if !occupied && p.Capture {
+		return illegal(NothingToCapture)
+	}
+	if !pos.movesVector(p.SourceSq).Occupied(p.DestinationSq) {
+		return illegal(UnreachableSquare)
+	}
+
+	promotes := pos.Bd.Piece(p.SourceSq).PieceType() == Pawn &&
+		(p.DestinationSq.Rank() == Rank1 || p.DestinationSq.Rank() == lastRank)
+	switch {
+	case promotes && p.Promotion == NoPieceType:
+		return illegal(MissingPromotion)
+	case !promotes && p.Promotion != NoPieceType:
+		return illegal(InvalidPromotion)
+	case promotes && !pos.Rules().isPromotionPieceType(p.Promotion):
+		return illegal(InvalidPromotion)
+	}
+
+	if !pos.leavesKingSafe(NewMove(p.SourceSq, p.DestinationSq, p.Promotion, p.Capture)) {
+		return illegal(LeavesKingInCheck)
+	}
+	return nil
+}
+
+// ApplyMove makes the ply if it is legal, otherwise leaves the position unchanged and returns the error from CheckMove.
+func (pos *Position) ApplyMove(p *Ply) error {
+	if err := pos.CheckMove(p); err != nil {
+		return err
+	}
+	pos.MakeMove(p)
+	return nil
+}
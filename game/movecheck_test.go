@@ -0,0 +1,75 @@
+package game
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestCheckMove checks that CheckMove gives each reason for the plies it rejects, and accepts legal ones, on a board
+// which fits in the corner of every board size.
+func TestCheckMove(t *testing.T) {
+	sq := GetSquare
+	bd := BoardFromMap(map[Square]Piece{
+		sq(FileA, Rank1):      WhiteKing,
+		sq(FileA, Rank2):      WhiteRook, // pinned by the black rook
+		sq(FileA, lastRank):   BlackRook,
+		sq(FileE, lastRank):   BlackKing,
+		sq(FileC, Rank1):      WhiteRook,
+		sq(FileC, Rank3):      BlackKnight,
+		sq(FileB, lastRank-1): WhitePawn,
+		sq(FileD, Rank2):      WhitePawn,
+	})
+	pos, err := NewValidPosition(bd, White, 0, 0, []uint64{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	before := pos.FEN()
+
+	ply := func(src, dst Square, promotion PieceType, capture bool) Ply {
+		return Ply{SourceSq: src, DestinationSq: dst, Promotion: promotion, Capture: capture, Side: White}
+	}
+	tests := []struct {
+		name string
+		ply  Ply
+		want IllegalMoveReason // 0 for a legal ply
+	}{
+		{"wrong side", Ply{SourceSq: sq(FileC, Rank3), DestinationSq: sq(FileB, Rank1), Side: Black}, WrongSide},
+		{"empty source square", ply(sq(FileB, Rank1), sq(FileB, Rank2), NoPieceType, false), NoOwnPiece},
+		{"opponent's piece", ply(sq(FileC, Rank3), sq(FileB, Rank1), NoPieceType, false), NoOwnPiece},
+		{"capture not marked", ply(sq(FileC, Rank1), sq(FileC, Rank3), NoPieceType, false), MissingCapture},
+		{"capture of an empty square", ply(sq(FileC, Rank1), sq(FileC, Rank2), NoPieceType, true), NothingToCapture},
+		{"rook moving diagonally", ply(sq(FileC, Rank1), sq(FileB, Rank2), NoPieceType, false), UnreachableSquare},
+		{"pawn reaching the last rank", ply(sq(FileB, lastRank-1), sq(FileB, lastRank), NoPieceType, false), MissingPromotion},
+		{"promotion to a king", ply(sq(FileB, lastRank-1), sq(FileB, lastRank), King, false), InvalidPromotion},
+		{"promotion before the last rank", ply(sq(FileD, Rank2), sq(FileD, Rank3), Queen, false), InvalidPromotion},
+		{"promotion of a rook", ply(sq(FileC, Rank1), sq(FileC, Rank2), Queen, false), InvalidPromotion},
+		{"pinned rook", ply(sq(FileA, Rank2), sq(FileB, Rank2), NoPieceType, false), LeavesKingInCheck},
+		{"king into check", ply(sq(FileA, Rank1), sq(FileB, Rank1), NoPieceType, false), LeavesKingInCheck},
+		{"capture", ply(sq(FileC, Rank1), sq(FileC, Rank3), NoPieceType, true), 0},
+		{"promotion", ply(sq(FileB, lastRank-1), sq(FileB, lastRank), Queen, false), 0},
+		{"pinned rook along the pin", ply(sq(FileA, Rank2), sq(FileA, lastRank), NoPieceType, true), 0},
+	}
+	for _, test := range tests {
+		err := pos.CheckMove(&test.ply)
+		var ime *IllegalMoveError
+		switch {
+		case test.want == 0 && err != nil:
+			t.Errorf("%s: CheckMove(%v) = %v, want nil", test.name, &test.ply, err)
+		case test.want != 0 && (!errors.As(err, &ime) || ime.Reason != test.want):
+			t.Errorf("%s: CheckMove(%v) = %v, want %s", test.name, &test.ply, err, test.want)
+		}
+		if after := pos.FEN(); after != before {
+			t.Fatalf("%s: CheckMove changed the position from %s to %s", test.name, before, after)
+		}
+	}
+
+	// ApplyMove leaves the position unchanged for an illegal ply, and makes a legal one
+	bad := ply(sq(FileC, Rank1), sq(FileC, Rank2), NoPieceType, true)
+	if err := pos.ApplyMove(&bad); err == nil || pos.FEN() != before {
+		t.Errorf("ApplyMove(%v) = %v, position %s", &bad, err, pos.FEN())
+	}
+	good := ply(sq(FileC, Rank1), sq(FileC, Rank3), NoPieceType, true)
+	if err := pos.ApplyMove(&good); err != nil || pos.Turn != Black || pos.Bd.Piece(sq(FileC, Rank3)) != WhiteRook {
+		t.Errorf("ApplyMove(%v) = %v, position %s", &good, err, pos.FEN())
+	}
+}
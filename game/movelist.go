@@ -0,0 +1,272 @@
+package game
+
+import "math/bits"
+
+// Move is a compact encoding of a ply, used by the allocation-free move generators. The side making the move is not
+// stored, as it is always the side to move in the position the Move was generated from.
+// Bits 0-5 are the source square, bits 6-11 the destination square, bits 12-14 the promotion PieceType and bit 15
+// whether the move is a capture.
+type Move uint16
+
+// NoMove is the zero Move, which is never generated.
+const NoMove Move = 0
+
+// NewMove constructs a Move.
+func NewMove(source, destination Square, promotion PieceType, capture bool) Move {
+	m := Move(source) | Move(destination)<<6 | Move(promotion)<<12
+	if capture {
+		m |= 1 << 15
+	}
+	return m
+}
+
+// MoveFromPly returns the compact Move for a ply.
+func MoveFromPly(p *Ply) Move {
+	return NewMove(p.SourceSq, p.DestinationSq, p.Promotion, p.Capture)
+}
+
+// Source returns the origin square.
+func (m Move) Source() Square {
+	return Square(m & 63)
+}
+
+// Destination returns the destination square.
+func (m Move) Destination() Square {
+	return Square(m >> 6 & 63)
+}
+
+// Promotion returns the PieceType being promoted to, NoPieceType if none.
+func (m Move) Promotion() PieceType {
+	return PieceType(m >> 12 & 7)
+}
+
+// Capture returns whether the move is a capture.
+func (m Move) Capture() bool {
+	return m>>15 == 1
+}
+
+// Ply returns the move as a Ply made by side.
+func (m Move) Ply(side Color) Ply {
+	return Ply{
+		SourceSq:      m.Source(),
+		DestinationSq: m.Destination(),
+		Promotion:     m.Promotion(),
+		Capture:       m.Capture(),
+		Side:          side,
+	}
+}
+
+// String provides the same representation as Ply.String.
+// Implements the fmt.Stringer interface.
+func (m Move) String() string {
+	p := m.Ply(NoColor)
+	return p.String()
+}
+
+//--------------------------------------------------------------------------------
+
+// MaxMoves is the capacity of a MoveList - comfortably more than the most moves possible in a Los Alamos position.
+const MaxMoves = 256
+
+// MoveList is a fixed-capacity list of moves, filled in place by the move generators so that no memory is allocated.
+// Declare one per search ply (e.g. as a local variable) and reuse it.
+type MoveList struct {
+	Moves [MaxMoves]Move
+	Count int
+}
+
+// Add appends a move to the list.
+func (ml *MoveList) Add(m Move) {
+	ml.Moves[ml.Count] = m
+	ml.Count++
+}
+
+// Clear empties the list.
+func (ml *MoveList) Clear() {
+	ml.Count = 0
+}
+
+// Slice returns the moves in the list, sharing its storage.
+func (ml *MoveList) Slice() []Move {
+	return ml.Moves[:ml.Count]
+}
+
+// Plies returns the moves in the list as a newly allocated slice of pointers to plies made by side.
+func (ml *MoveList) Plies(side Color) []*Ply {
+	if ml.Count == 0 {
+		return nil
+	}
+	plies := make([]Ply, ml.Count)
+	ptrs := make([]*Ply, ml.Count)
+	for i := 0; i < ml.Count; i++ {
+		plies[i] = ml.Moves[i].Ply(side)
+		ptrs[i] = &plies[i]
+	}
+	return ptrs
+}
+
+// MakeCompactMove is MakeMove for a Move generated in this position.
+func (pos *Position) MakeCompactMove(m Move) Undo {
+	p := m.Ply(pos.Turn)
+	return pos.MakeMove(&p)
+}
+
+//--------------------------------------------------------------------------------
+
+// moveKinds selects which kinds of moves generateMoves emits.
+type moveKinds uint8
+
+const (
+	// tacticalMoves are captures and promotions
+	tacticalMoves moveKinds = 1 << iota
+	// quietMoves are all other moves
+	quietMoves
+
+	allMoves = tacticalMoves | quietMoves
+)
+
+// GeneratePseudoLegalMoveList fills ml with the pseudolegal moves in the position, in the same order as
+// GeneratePseudoLegalMoves.
+func (pos *Position) GeneratePseudoLegalMoveList(ml *MoveList) {
+	pos.generateMoves(ml, allMoves, nil)
+}
+
+// GenerateLegalMoveList fills ml with the legal moves in the position, in the same order as GenerateLegalMoves.
+// Checkers and pins are worked out once for the position, so only legal moves are generated.
+func (pos *Position) GenerateLegalMoveList(ml *MoveList) {
+	var li legalityInfo
+	pos.legalityInfo(&li)
+	pos.generateMoves(ml, allMoves, &li)
+}
+
+// GenerateFilteredLegalMoveList fills ml with the same moves as GenerateLegalMoveList, by making each pseudolegal move
+// on a copy of the board and checking whether the king is attacked. It is much slower, and is kept as a reference to
+// check GenerateLegalMoveList against.
+func (pos *Position) GenerateFilteredLegalMoveList(ml *MoveList) {
+	pos.generateMoves(ml, allMoves, nil)
+	pos.filterLegal(ml)
+}
+
+// GenerateTacticalMoveList fills ml with the legal captures and promotions (including non-capturing promotions).
+func (pos *Position) GenerateTacticalMoveList(ml *MoveList) {
+	var li legalityInfo
+	pos.legalityInfo(&li)
+	pos.generateMoves(ml, tacticalMoves, &li)
+}
+
+// GenerateQuietMoveList fills ml with the legal moves which are neither captures nor promotions.
+func (pos *Position) GenerateQuietMoveList(ml *MoveList) {
+	var li legalityInfo
+	pos.legalityInfo(&li)
+	pos.generateMoves(ml, quietMoves, &li)
+}
+
+// GenerateEvasionMoveList fills ml with the legal moves when the side to move is in check - king moves, captures of a
+// single checking piece and blocks of a single sliding check. Only the pieces which could make such a move are
+// looked at: the king alone in double check, and otherwise the pieces attacking the checker or a square between it
+// and the king, and the pawns which can push onto one. If the side to move is not in check, this is the same as
+// GenerateLegalMoveList.
+func (pos *Position) GenerateEvasionMoveList(ml *MoveList) {
+	var li legalityInfo
+	pos.legalityInfo(&li)
+	b := pos.Bd
+	own := b.PieceOccupancy(pos.Turn)
+
+	movers := own
+	if li.checkers != 0 {
+		movers = b.BitBoardForPiece(NewPiece(King, pos.Turn))
+	}
+	if bits.OnesCount64(uint64(li.checkers)) == 1 {
+		occupied := b.wPieces | b.bPieces
+		for targets := li.checkMask; targets != 0; targets &= targets - 1 {
+			movers |= b.AttackersTo(Square(bits.TrailingZeros64(uint64(targets))), occupied) & own
+		}
+		// pawns are only attackers of the squares they capture on, not those they push to
+		for pawns := b.BitBoardForPiece(NewPiece(Pawn, pos.Turn)) &^ movers; pawns != 0; pawns &= pawns - 1 {
+			sq := Square(bits.TrailingZeros64(uint64(pawns)))
+			if pos.movesVector(sq)&li.checkMask != 0 {
+				movers |= sq.BitBoard()
+			}
+		}
+	}
+	pos.generateMovesFrom(ml, allMoves, &li, movers)
+}
+
+// generateMoves fills ml with the moves of the given kinds. If li is nil the moves are pseudolegal, otherwise only
+// the legal moves are generated: the king may not move to a danger square, other pieces must resolve any check and
+// pinned pieces must stay on their pin ray.
+func (pos *Position) generateMoves(ml *MoveList, kinds moveKinds, li *legalityInfo) {
+	pos.Bd.UpdateConvenienceBBs()
+	pos.generateMovesFrom(ml, kinds, li, pos.Bd.PieceOccupancy(pos.Turn))
+}
+
+// generateMovesFrom is generateMoves for the moves of the pieces in movers only. The convenience bitboards must be up
+// to date.
+func (pos *Position) generateMovesFrom(ml *MoveList, kinds moveKinds, li *legalityInfo, movers BitBoard) {
+	ml.Clear()
+
+	// get other side's pieces - needed for calculating captures
+	otherPieces := pos.Bd.PieceOccupancy(pos.Turn.Other())
+	pawns := pos.Bd.BitBoardForPiece(NewPiece(Pawn, pos.Turn))
+	king := pos.Bd.BitBoardForPiece(NewPiece(King, pos.Turn))
+	promotionPieceTypes := pos.Rules().PromotionPieceTypes
+
+	// loop over source squares
+	for ; movers != 0; movers &= movers - 1 {
+		startSq := Square(bits.TrailingZeros64(uint64(movers)))
+		isPawn := pawns.Occupied(startSq)
+		mvVector := pos.movesVector(startSq)
+		if li != nil {
+			switch {
+			case king.Occupied(startSq):
+				mvVector &^= li.danger
+			case li.pinned.Occupied(startSq):
+				mvVector &= li.checkMask & li.pinRays[startSq]
+			default:
+				mvVector &= li.checkMask
+			}
+		}
+		// loop over destination squares
+		for ; mvVector != 0; mvVector &= mvVector - 1 {
+			endSq := Square(bits.TrailingZeros64(uint64(mvVector)))
+			capture := otherPieces.Occupied(endSq)
+			// pawns reaching the 1st or last ranks must promote
+			promotion := isPawn && (endSq.Rank() == Rank1 || endSq.Rank() == lastRank)
+
+			if capture || promotion {
+				if kinds&tacticalMoves == 0 {
+					continue
+				}
+			} else if kinds&quietMoves == 0 {
+				continue
+			}
+
+			if promotion {
+				for _, pt := range promotionPieceTypes {
+					ml.Add(NewMove(startSq, endSq, pt, capture))
+				}
+			} else {
+				ml.Add(NewMove(startSq, endSq, NoPieceType, capture))
+			}
+		}
+	}
+}
+
+// filterLegal removes the moves which leave the side to move's king attacked, keeping the order of the rest.
+func (pos *Position) filterLegal(ml *MoveList) {
+	n := 0
+	for i := 0; i < ml.Count; i++ {
+		if pos.leavesKingSafe(ml.Moves[i]) {
+			ml.Moves[n] = ml.Moves[i]
+			n++
+		}
+	}
+	ml.Count = n
+}
+
+// leavesKingSafe returns whether the side to move's king is not attacked after the pseudolegal move m.
+func (pos *Position) leavesKingSafe(m Move) bool {
+	bd := *pos.Bd
+	bd.Move(m.Source(), m.Destination(), NewPiece(m.Promotion(), pos.Turn))
+	return !bd.InCheck(pos.Turn)
+}
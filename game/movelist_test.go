@@ -0,0 +1,151 @@
+package game
+
+import (
+	"sort"
+	"testing"
+)
+
+// TestLegalMoveList checks GenerateLegalMoveList against GenerateFilteredLegalMoveList, which makes each pseudolegal
+// move and tests for check, in every position reached in three plies from the perft positions.
+func TestLegalMoveList(t *testing.T) {
+	positions := 0
+	var walk func(pos *Position, depth int)
+	walk = func(pos *Position, depth int) {
+		var legal, filtered MoveList
+		pos.GenerateLegalMoveList(&legal)
+		pos.GenerateFilteredLegalMoveList(&filtered)
+		positions++
+		if got, want := sortedMoves(&legal), sortedMoves(&filtered); !equalMoves(got, want) {
+			t.Fatalf("%s: legal moves %v, filtered pseudolegal moves %v", pos.FEN(), got, want)
+		}
+		if depth == 0 {
+			return
+		}
+		for _, m := range legal.Slice() {
+			u := pos.MakeCompactMove(m)
+			walk(pos, depth-1)
+			pos.UnmakeMove(u)
+		}
+	}
+	for _, pr := range PerftResults {
+		pos, err := PositionFromFEN(pr.FEN)
+		if err != nil {
+			t.Fatalf("%s: %v", pr.Name, err)
+		}
+		walk(pos, 3)
+	}
+	t.Logf("%d positions checked", positions)
+}
+
+// TestEvasionMoveList checks GenerateEvasionMoveList against GenerateLegalMoveList in every position reached in
+// three plies from the perft positions, and that some of them were in check.
+func TestEvasionMoveList(t *testing.T) {
+	checks := 0
+	var walk func(pos *Position, depth int)
+	walk = func(pos *Position, depth int) {
+		var legal, evasions MoveList
+		pos.GenerateLegalMoveList(&legal)
+		pos.GenerateEvasionMoveList(&evasions)
+		if pos.InCheck {
+			checks++
+		}
+		if got, want := sortedMoves(&evasions), sortedMoves(&legal); !equalMoves(got, want) {
+			t.Fatalf("%s: evasions %v, legal moves %v", pos.FEN(), got, want)
+		}
+		if depth == 0 {
+			return
+		}
+		for _, m := range legal.Slice() {
+			u := pos.MakeCompactMove(m)
+			walk(pos, depth-1)
+			pos.UnmakeMove(u)
+		}
+	}
+	for _, pr := range PerftResults {
+		pos, err := PositionFromFEN(pr.FEN)
+		if err != nil {
+			t.Fatalf("%s: %v", pr.Name, err)
+		}
+		walk(pos, 3)
+	}
+	if checks == 0 {
+		t.Error("no positions in check were reached")
+	}
+}
+
+func sortedMoves(ml *MoveList) []Move {
+	moves := append([]Move{}, ml.Slice()...)
+	sort.Slice(moves, func(i, j int) bool { return moves[i] < moves[j] })
+	return moves
+}
+
+func equalMoves(a, b []Move) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// TestMovePacking checks that every combination of squares, promotion and capture is read back from a Move, and from
+// the Ply it converts to and from.
+func TestMovePacking(t *testing.T) {
+	for src := Square(0); src < numSquaresInBoard; src++ {
+		for dst := Square(0); dst < numSquaresInBoard; dst++ {
+			for _, promotion := range []PieceType{NoPieceType, Knight, Bishop, Rook, Queen} {
+				for _, capture := range []bool{false, true} {
+					m := NewMove(src, dst, promotion, capture)
+					if m.Source() != src || m.Destination() != dst || m.Promotion() != promotion || m.Capture() != capture {
+						t.Fatalf("NewMove(%s, %s, %s, %v) unpacks to %s, %s, %s, %v", src, dst, promotion, capture,
+							m.Source(), m.Destination(), m.Promotion(), m.Capture())
+					}
+					p := m.Ply(Black)
+					want := Ply{SourceSq: src, DestinationSq: dst, Promotion: promotion, Capture: capture, Side: Black}
+					if !p.Equals(&want) || MoveFromPly(&p) != m {
+						t.Fatalf("%v converts to the ply %v and back to %v", m, &p, MoveFromPly(&p))
+					}
+				}
+			}
+		}
+	}
+}
+
+// TestMoveListAllocs checks that the MoveList generators allocate nothing.
+func TestMoveListAllocs(t *testing.T) {
+	var ml MoveList
+	generators := map[string]func(pos *Position){
+		"GenerateLegalMoveList":       func(pos *Position) { pos.GenerateLegalMoveList(&ml) },
+		"GeneratePseudoLegalMoveList": func(pos *Position) { pos.GeneratePseudoLegalMoveList(&ml) },
+		"GenerateTacticalMoveList":    func(pos *Position) { pos.GenerateTacticalMoveList(&ml) },
+		"GenerateQuietMoveList":       func(pos *Position) { pos.GenerateQuietMoveList(&ml) },
+		"GenerateEvasionMoveList":     func(pos *Position) { pos.GenerateEvasionMoveList(&ml) },
+	}
+	for _, pr := range PerftResults {
+		pos, err := PositionFromFEN(pr.FEN)
+		if err != nil {
+			t.Fatalf("%s: %v", pr.Name, err)
+		}
+		for name, generate := range generators {
+			if allocs := testing.AllocsPerRun(100, func() { generate(pos) }); allocs != 0 {
+				t.Errorf("%s: %s allocates %v times", pr.Name, name, allocs)
+			}
+		}
+	}
+}
+
+// BenchmarkGenerateLegalMoveList times generating the legal moves of the starting position, which allocates nothing.
+func BenchmarkGenerateLegalMoveList(b *testing.B) {
+	pos, err := PositionFromFEN(PerftResults[0].FEN)
+	if err != nil {
+		b.Fatal(err)
+	}
+	var ml MoveList
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		pos.GenerateLegalMoveList(&ml)
+	}
+}
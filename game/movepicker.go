@@ -0,0 +1,301 @@
+package game
+
+// Stages of a MovePicker
+const (
+	pickTTMove = iota
+	pickGoodTactical
+	pickKillers
+	pickQuiet
+	// pickRest hands out the moves left in the tactical list - the captures which lose material, or every move when
+	// the moves were generated together
+	pickRest
+	pickDone
+)
+
+// Move ordering scores of check evasions, which are all handed out in one stage: the transposition table move, then
+// captures which do not lose material, killer moves, quiet moves by their history and lastly captures which lose
+// material. Tactical moves out of check are scored by their MVV-LVA score, offset by goodCaptureScore or
+// badCaptureScore.
+const (
+	ttMoveScore      int32 = 1 << 30
+	goodCaptureScore int32 = 1 << 28
+	killerScore      int32 = 1 << 26
+	badCaptureScore  int32 = -1 << 28
+)
+
+// pickValues are rough piece values used to order captures and promotions, most valuable victim first and then
+// least valuable attacker
+var pickValues = [numPieceTypes]int32{
+	Pawn:   1,
+	Knight: 3,
+	Bishop: 3,
+	Rook:   5,
+	Queen:  9,
+	King:   20,
+}
+
+// pickSEEWeights are pickValues as weights for SEE, which only a losing capture needs
+var pickSEEWeights = map[PieceType]float32{
+	Pawn:   1,
+	Knight: 3,
+	Bishop: 3,
+	Rook:   5,
+	Queen:  9,
+}
+
+// QuietScorer scores the quiet moves handed out by a MovePicker, which are tried in decreasing order of score - for
+// example by how often they have caused cutoffs in a search.
+type QuietScorer interface {
+	ScoreQuiet(side Color, m Move) int32
+}
+
+// MovePicker hands out the legal moves of a position one stage at a time, in the order a search is most likely to
+// find a cutoff: the transposition table move, captures and promotions which do not lose material (by most valuable
+// victim, then least valuable attacker), the killer moves, the other quiet moves by their QuietScorer score, and then
+// the captures which lose material. Each stage is only generated once the previous one is used up, so a search that
+// cuts off after a capture never generates the quiet moves. In check, the evasions are generated and ordered together.
+//
+// The transposition table move and killers may come from other positions - they are only handed out if legal.
+// The position must be unchanged (any moves made must have been unmade) between calls to Next.
+type MovePicker struct {
+	// Unordered hands out the moves in the order they are generated, with the transposition table move first, e.g.
+	// to measure what move ordering saves. It must be set before Init.
+	Unordered bool
+
+	pos          *Position
+	stage        int
+	tacticalOnly bool
+	ttMove       Move
+	killers      [2]Move
+	history      QuietScorer
+
+	tactical       MoveList
+	tacticalScores [MaxMoves]int32
+	tacticalIndex  int
+
+	quiet          MoveList
+	quietScores    [MaxMoves]int32
+	quietIndex     int
+	quietGenerated bool
+	killerIndex    int
+}
+
+// Init prepares the picker for the position, with the transposition table move ttMove (NoMove if none), the killer
+// moves of the search ply and the scorer of the other quiet moves (nil to leave them in the order generated), so that
+// a MovePicker can be declared once and reused without allocating.
+func (mp *MovePicker) Init(pos *Position, ttMove Move, killers [2]Move, history QuietScorer) {
+	mp.init(pos, ttMove, killers, history, false)
+}
+
+// InitTactical prepares the picker for a quiescence search of the position: only captures and promotions are handed
+// out, except in check, when every evasion is.
+func (mp *MovePicker) InitTactical(pos *Position) {
+	mp.init(pos, NoMove, [2]Move{}, nil, true)
+}
+
+func (mp *MovePicker) init(pos *Position, ttMove Move, killers [2]Move, history QuietScorer, tacticalOnly bool) {
+	mp.pos = pos
+	mp.tacticalOnly = tacticalOnly
+	mp.ttMove = ttMove
+	mp.killers = killers
+	mp.history = history
+	mp.tactical.Clear()
+	mp.tacticalIndex = 0
+	mp.quiet.Clear()
+	mp.quietIndex = 0
+	mp.quietGenerated = false
+	mp.killerIndex = 0
+
+	switch {
+	case mp.Unordered:
+		if tacticalOnly && !pos.InCheck {
+			pos.GenerateTacticalMoveList(&mp.tactical)
+		} else {
+			pos.GenerateLegalMoveList(&mp.tactical)
+		}
+		moveToFront(&mp.tactical, ttMove)
+		mp.stage = pickRest
+	case pos.InCheck:
+		pos.GenerateEvasionMoveList(&mp.tactical)
+		mp.scoreEvasions()
+		mp.stage = pickRest
+	default:
+		pos.GenerateTacticalMoveList(&mp.tactical)
+		mp.stage = pickTTMove
+	}
+}
+
+// Next returns the next move, and false once there are no moves left.
+func (mp *MovePicker) Next() (Move, bool) {
+	for {
+		switch mp.stage {
+		case pickTTMove:
+			mp.stage = pickGoodTactical
+			found := false
+			if mp.ttMove.Capture() || mp.ttMove.Promotion() != NoPieceType {
+				found = remove(&mp.tactical, mp.ttMove)
+			} else if mp.ttMove != NoMove && !mp.tacticalOnly {
+				mp.generateQuiet()
+				found = remove(&mp.quiet, mp.ttMove)
+			}
+			mp.scoreTactical()
+			if found {
+				return mp.ttMove, true
+			}
+
+		case pickGoodTactical:
+			if i := mp.tacticalIndex; i < mp.tactical.Count {
+				pickBest(&mp.tactical, &mp.tacticalScores, i)
+				if mp.tacticalScores[i] >= 0 {
+					mp.tacticalIndex++
+					return mp.tactical.Moves[i], true
+				}
+			}
+			if mp.tacticalOnly {
+				mp.stage = pickRest
+			} else {
+				mp.stage = pickKillers
+			}
+
+		case pickKillers:
+			mp.generateQuiet()
+			for mp.killerIndex < len(mp.killers) {
+				k := mp.killers[mp.killerIndex]
+				mp.killerIndex++
+				if k != NoMove && remove(&mp.quiet, k) {
+					return k, true
+				}
+			}
+			mp.scoreQuiet()
+			mp.stage = pickQuiet
+
+		case pickQuiet:
+			if i := mp.quietIndex; i < mp.quiet.Count {
+				if mp.history != nil {
+					pickBest(&mp.quiet, &mp.quietScores, i)
+				}
+				mp.quietIndex++
+				return mp.quiet.Moves[i], true
+			}
+			mp.stage = pickRest
+
+		case pickRest:
+			if i := mp.tacticalIndex; i < mp.tactical.Count {
+				if !mp.Unordered {
+					pickBest(&mp.tactical, &mp.tacticalScores, i)
+				}
+				mp.tacticalIndex++
+				return mp.tactical.Moves[i], true
+			}
+			mp.stage = pickDone
+
+		default:
+			return NoMove, false
+		}
+	}
+}
+
+// generateQuiet generates the quiet moves, if they have not been already.
+func (mp *MovePicker) generateQuiet() {
+	if !mp.quietGenerated {
+		mp.pos.GenerateQuietMoveList(&mp.quiet)
+		mp.quietGenerated = true
+	}
+}
+
+// scoreTactical scores the captures and promotions by MVV-LVA, negative for those which lose material.
+func (mp *MovePicker) scoreTactical() {
+	for i, m := range mp.tactical.Slice() {
+		mp.tacticalScores[i] = mp.tacticalScore(m)
+	}
+}
+
+// tacticalScore returns the MVV-LVA score of a capture or promotion, offset by badCaptureScore if it loses material.
+func (mp *MovePicker) tacticalScore(m Move) int32 {
+	b := mp.pos.Bd
+	victim := pickValues[b.Piece(m.Destination()).PieceType()]
+	attacker := pickValues[b.Piece(m.Source()).PieceType()]
+	if m.Promotion() != NoPieceType {
+		victim += pickValues[m.Promotion()] - pickValues[Pawn]
+	}
+	score := victim*32 - attacker
+	// only a capture by a more valuable piece can lose material
+	if victim < attacker {
+		p := m.Ply(mp.pos.Turn)
+		if b.SEE(&p, pickSEEWeights) < 0 {
+			return badCaptureScore + score
+		}
+	}
+	return score
+}
+
+// scoreQuiet scores the quiet moves with the QuietScorer, if there is one.
+func (mp *MovePicker) scoreQuiet() {
+	if mp.history == nil {
+		return
+	}
+	for i, m := range mp.quiet.Slice() {
+		mp.quietScores[i] = mp.history.ScoreQuiet(mp.pos.Turn, m)
+	}
+}
+
+// scoreEvasions scores the check evasions, which are all generated at once, in the order of the stages out of check.
+func (mp *MovePicker) scoreEvasions() {
+	for i, m := range mp.tactical.Slice() {
+		var score int32
+		switch {
+		case m == mp.ttMove:
+			score = ttMoveScore
+		case m.Capture() || m.Promotion() != NoPieceType:
+			score = mp.tacticalScore(m)
+			if score >= 0 {
+				score += goodCaptureScore
+			}
+		case m == mp.killers[0]:
+			score = killerScore + 1
+		case m == mp.killers[1]:
+			score = killerScore
+		case mp.history != nil:
+			score = mp.history.ScoreQuiet(mp.pos.Turn, m)
+		}
+		mp.tacticalScores[i] = score
+	}
+}
+
+// pickBest moves the move with the highest score at or after from up to from, keeping the order of those it passes
+// so that moves with the same score keep their order.
+func pickBest(ml *MoveList, scores *[MaxMoves]int32, from int) {
+	best := from
+	for i := from + 1; i < ml.Count; i++ {
+		if scores[i] > scores[best] {
+			best = i
+		}
+	}
+	m, score := ml.Moves[best], scores[best]
+	copy(ml.Moves[from+1:best+1], ml.Moves[from:best])
+	copy(scores[from+1:best+1], scores[from:best])
+	ml.Moves[from], scores[from] = m, score
+}
+
+// remove removes m from ml, keeping the order of the other moves, and returns whether it was there.
+func remove(ml *MoveList, m Move) bool {
+	for i, lm := range ml.Slice() {
+		if lm == m {
+			copy(ml.Moves[i:ml.Count-1], ml.Moves[i+1:ml.Count])
+			ml.Count--
+			return true
+		}
+	}
+	return false
+}
+
+// moveToFront moves m, if it is in ml, to the front of ml, keeping the order of the other moves.
+func moveToFront(ml *MoveList, m Move) {
+	for i, lm := range ml.Slice() {
+		if lm == m {
+			copy(ml.Moves[1:i+1], ml.Moves[:i])
+			ml.Moves[0] = m
+			return
+		}
+	}
+}
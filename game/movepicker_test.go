@@ -0,0 +1,125 @@
+package game
+
+import "testing"
+
+// destinationScorer scores quiet moves by their destination square, so the order they are picked in is known.
+type destinationScorer struct{}
+
+func (destinationScorer) ScoreQuiet(side Color, m Move) int32 {
+	return int32(m.Destination())
+}
+
+// TestMovePicker checks that a MovePicker hands out every legal move once in each position reached in two plies from
+// the perft positions, whatever transposition table move and killers it is given, with the transposition table move
+// first if legal, legal killers before the other quiet moves and those by decreasing score.
+func TestMovePicker(t *testing.T) {
+	illegal := NewMove(Square(1), Square(1), NoPieceType, false)
+	var walk func(pos *Position, depth int)
+	walk = func(pos *Position, depth int) {
+		var legal, quiet MoveList
+		pos.GenerateLegalMoveList(&legal)
+		pos.GenerateQuietMoveList(&quiet)
+		if legal.Count == 0 {
+			return
+		}
+		isQuiet := map[Move]bool{}
+		for _, m := range quiet.Slice() {
+			isQuiet[m] = true
+		}
+		killers := [2]Move{illegal, NoMove}
+		if quiet.Count > 0 {
+			killers[1] = quiet.Moves[quiet.Count-1]
+		}
+
+		for _, ttMove := range []Move{NoMove, illegal, legal.Moves[0], legal.Moves[legal.Count-1]} {
+			var mp MovePicker
+			mp.Init(pos, ttMove, killers, destinationScorer{})
+			var picked MoveList
+			for m, ok := mp.Next(); ok; m, ok = mp.Next() {
+				picked.Add(m)
+			}
+			if got, want := sortedMoves(&picked), sortedMoves(&legal); !equalMoves(got, want) {
+				t.Fatalf("%s, TT move %v: picked %v, legal moves %v", pos.FEN(), ttMove, got, want)
+			}
+			if ttMove != NoMove && ttMove != illegal && picked.Moves[0] != ttMove {
+				t.Errorf("%s: picked %v first, not TT move %v", pos.FEN(), picked.Moves[0], ttMove)
+			}
+			if pos.InCheck {
+				continue
+			}
+
+			// the quiet moves other than the TT move and killers follow the killers, by decreasing score
+			killerSeen, lastScore := false, int32(1<<30)
+			for _, m := range picked.Slice() {
+				switch {
+				case !isQuiet[m] || m == ttMove:
+				case m == killers[1]:
+					killerSeen = true
+					if lastScore != 1<<30 {
+						t.Errorf("%s: killer %v picked after other quiet moves %v", pos.FEN(), m, picked.Slice())
+					}
+				default:
+					score := destinationScorer{}.ScoreQuiet(pos.Turn, m)
+					if score > lastScore {
+						t.Errorf("%s: quiet moves not picked by score: %v", pos.FEN(), picked.Slice())
+					}
+					lastScore = score
+				}
+			}
+			if killers[1] != NoMove && killers[1] != ttMove && !killerSeen {
+				t.Errorf("%s: killer %v not picked", pos.FEN(), killers[1])
+			}
+		}
+
+		// a quiescence search gets the captures and promotions, or every evasion in check
+		var tactical, picked MoveList
+		if pos.InCheck {
+			pos.GenerateLegalMoveList(&tactical)
+		} else {
+			pos.GenerateTacticalMoveList(&tactical)
+		}
+		var mp MovePicker
+		mp.InitTactical(pos)
+		for m, ok := mp.Next(); ok; m, ok = mp.Next() {
+			picked.Add(m)
+		}
+		if got, want := sortedMoves(&picked), sortedMoves(&tactical); !equalMoves(got, want) {
+			t.Fatalf("%s: picked tactical moves %v, want %v", pos.FEN(), got, want)
+		}
+
+		// unordered, the moves come in the order generated after the TT move
+		ttMove := legal.Moves[legal.Count-1]
+		mp = MovePicker{Unordered: true}
+		mp.Init(pos, ttMove, killers, destinationScorer{})
+		want := append([]Move{ttMove}, legal.Moves[:legal.Count-1]...)
+		for i := 0; ; i++ {
+			m, ok := mp.Next()
+			if !ok {
+				if i != len(want) {
+					t.Errorf("%s: unordered picker stopped after %d moves, want %d", pos.FEN(), i, len(want))
+				}
+				break
+			}
+			if i >= len(want) || m != want[i] {
+				t.Errorf("%s: unordered picker gave %v as move %d, want %v", pos.FEN(), m, i, want)
+				break
+			}
+		}
+
+		if depth == 0 {
+			return
+		}
+		for _, m := range legal.Slice() {
+			u := pos.MakeCompactMove(m)
+			walk(pos, depth-1)
+			pos.UnmakeMove(u)
+		}
+	}
+	for _, pr := range PerftResults {
+		pos, err := PositionFromFEN(pr.FEN)
+		if err != nil {
+			t.Fatalf("%s: %v", pr.Name, err)
+		}
+		walk(pos, 2)
+	}
+}
@@ -0,0 +1,162 @@
+package game
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// Perft returns the number of leaf nodes of the legal move tree to the given depth, used to validate move generation.
+func (pos *Position) Perft(depth uint) uint64 {
+	if depth == 0 {
+		return 1
+	}
+	var ml MoveList
+	pos.GenerateLegalMoveList(&ml)
+	if depth == 1 {
+		return uint64(ml.Count)
+	}
+	var nodes uint64
+	for _, m := range ml.Slice() {
+		u := pos.MakeCompactMove(m)
+		nodes += pos.Perft(depth - 1)
+		pos.UnmakeMove(u)
+	}
+	return nodes
+}
+
+// PerftDivideEntry is the perft node count below a single root move.
+type PerftDivideEntry struct {
+	Ply   *Ply
+	Nodes uint64
+}
+
+// PerftDivide returns the perft node count to the given depth below each legal move, in move generation order.
+// Comparing these against another move generator narrows a perft mismatch down to a single move.
+func (pos *Position) PerftDivide(depth uint) []PerftDivideEntry {
+	if depth == 0 {
+		return nil
+	}
+	var entries []PerftDivideEntry
+	for _, lgm := range pos.GenerateLegalMoves() {
+		u := pos.MakeMove(lgm)
+		entries = append(entries, PerftDivideEntry{Ply: lgm, Nodes: pos.Perft(depth - 1)})
+		pos.UnmakeMove(u)
+	}
+	return entries
+}
+
+// PerftParallel returns the same count as Perft, splitting the root moves between threads and sharing a hash table of
+// subtree counts between them. tableBits sets the table size to 2^tableBits entries (16 bytes each).
+func (pos *Position) PerftParallel(depth uint, threads int, tableBits uint) uint64 {
+	if depth <= 1 || threads < 1 {
+		return pos.Perft(depth)
+	}
+	tt := newPerftTable(tableBits)
+	jobs := make(chan *Ply)
+	var nodes uint64
+	var wg sync.WaitGroup
+
+	for i := 0; i < threads; i++ {
+		wg.Add(1)
+		go func(p *Position) {
+			defer wg.Done()
+			for lgm := range jobs {
+				u := p.MakeMove(lgm)
+				atomic.AddUint64(&nodes, p.perftHashed(depth-1, tt))
+				p.UnmakeMove(u)
+			}
+		}(pos.Copy())
+	}
+	for _, lgm := range pos.GenerateLegalMoves() {
+		jobs <- lgm
+	}
+	close(jobs)
+	wg.Wait()
+	return nodes
+}
+
+// perftHashed is Perft with subtree counts cached in tt.
+func (pos *Position) perftHashed(depth uint, tt *perftTable) uint64 {
+	if depth <= 1 {
+		return pos.Perft(depth)
+	}
+	if nodes, ok := tt.probe(pos.Hash, depth); ok {
+		return nodes
+	}
+	var ml MoveList
+	pos.GenerateLegalMoveList(&ml)
+	var nodes uint64
+	for _, m := range ml.Slice() {
+		u := pos.MakeCompactMove(m)
+		nodes += pos.perftHashed(depth-1, tt)
+		pos.UnmakeMove(u)
+	}
+	tt.store(pos.Hash, depth, nodes)
+	return nodes
+}
+
+// perftTable is a lockless hash table of perft subtree counts. Each entry stores the data (depth in the top 8 bits,
+// node count in the rest) and the key XORed with the data, so an entry torn by concurrent writes fails to match.
+type perftTable struct {
+	entries []perftEntry
+	mask    uint64
+}
+
+type perftEntry struct {
+	check uint64
+	data  uint64
+}
+
+func newPerftTable(bits uint) *perftTable {
+	return &perftTable{
+		entries: make([]perftEntry, 1<<bits),
+		mask:    1<<bits - 1,
+	}
+}
+
+func (tt *perftTable) probe(hash uint64, depth uint) (uint64, bool) {
+	e := &tt.entries[hash&tt.mask]
+	data := atomic.LoadUint64(&e.data)
+	if atomic.LoadUint64(&e.check)^data != hash || data>>56 != uint64(depth) {
+		return 0, false
+	}
+	return data & (1<<56 - 1), true
+}
+
+func (tt *perftTable) store(hash uint64, depth uint, nodes uint64) {
+	e := &tt.entries[hash&tt.mask]
+	data := uint64(depth)<<56 | nodes&(1<<56-1)
+	atomic.StoreUint64(&e.data, data)
+	atomic.StoreUint64(&e.check, hash^data)
+}
+
+//--------------------------------------------------------------------------------
+
+// PerftResult is a position and its known-good perft counts - Counts[i] is the count at depth i+1.
+type PerftResult struct {
+	Name   string
+	FEN    string
+	Counts []uint64
+}
+
+// CheckPerftResults runs perft on every position in PerftResults up to maxDepth, returning an error describing the
+// first count that does not match.
+func CheckPerftResults(maxDepth uint) error {
+	for _, pr := range PerftResults {
+		pos, err := PositionFromFEN(pr.FEN)
+		if err != nil {
+			return fmt.Errorf("perft %s: %v", pr.Name, err)
+		}
+		for i, want := range pr.Counts {
+			depth := uint(i + 1)
+			if depth > maxDepth {
+				break
+			}
+			if got := pos.Perft(depth); got != want {
+				return fmt.Errorf("perft %s depth %d: got %d nodes, want %d", pr.Name, depth, got, want)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package game
+
+import "testing"
+
+// TestPerftResults checks the move generator against the known-good counts for the board size being built.
+func TestPerftResults(t *testing.T) {
+	if err := CheckPerftResults(6); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestPerftVariants checks that PerftParallel and PerftDivide count the same nodes as Perft.
+func TestPerftVariants(t *testing.T) {
+	for _, pr := range PerftResults {
+		pos, err := PositionFromFEN(pr.FEN)
+		if err != nil {
+			t.Fatalf("%s: %v", pr.Name, err)
+		}
+		before := pos.FEN()
+		depth := uint(len(pr.Counts))
+		if depth > 4 {
+			depth = 4
+		}
+		want := pos.Perft(depth)
+
+		if got := pos.PerftParallel(depth, 4, 12); got != want {
+			t.Errorf("%s: PerftParallel(%d) = %d, Perft = %d", pr.Name, depth, got, want)
+		}
+
+		var total uint64
+		for _, e := range pos.PerftDivide(depth) {
+			total += e.Nodes
+		}
+		if total != want {
+			t.Errorf("%s: PerftDivide(%d) totals %d, Perft = %d", pr.Name, depth, total, want)
+		}
+		if after := pos.FEN(); after != before {
+			t.Errorf("%s: position changed by perft from %s to %s", pr.Name, before, after)
+		}
+	}
+}
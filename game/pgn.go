@@ -0,0 +1,379 @@
+package game
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strings"
+)
+
+// pgnTagOrder is the order tags are written in - the Seven Tag Roster, then the termination, variant and setup tags.
+// Any other tags follow in alphabetical order.
+var pgnTagOrder = []string{"Event", "Site", "Date", "Round", "White", "Black", "Result", "Termination", "Variant", "SetUp", "FEN"}
+
+// Record is a record of a game - its tags, starting position, plies and result - which can be written to and read from PGN.
+type Record struct {
+	// PGN tags, e.g. "Event" or "White". Result, Termination, Variant, SetUp and FEN are filled in when writing.
+	Tags map[string]string
+
+	// Position the game started from
+	StartPos *Position
+
+	// Plies played in the game
+	Plies []*Ply
+
+	// Comment following each ply - Comments[i] belongs to Plies[i], "" means no comment
+	Comments []string
+
+	// Result of the game
+	Result Result
+
+	// Termination is why the game ended, NoTermination if it is in play (or unknown)
+	Termination Termination
+}
+
+// NewRecord returns an empty Record of a game starting from pos (which is copied).
+func NewRecord(pos *Position) *Record {
+	return &Record{
+		Tags:     map[string]string{},
+		StartPos: pos.Copy(),
+		Result:   InPlay,
+	}
+}
+
+// AddPly appends a ply, with an optional comment, to the record.
+func (r *Record) AddPly(p *Ply, comment string) {
+	r.Plies = append(r.Plies, p)
+	r.Comments = append(r.Comments, comment)
+}
+
+// FinalPosition returns the position reached by playing all the plies of the record from its starting position.
+func (r *Record) FinalPosition() *Position {
+	pos := r.StartPos.Copy()
+	for _, p := range r.Plies {
+		pos.UnsafeMove(p)
+	}
+	return pos
+}
+
+// PGNTermination returns the value of the PGN Termination tag for a Termination. PGN only distinguishes "normal"
+// endings from "time forfeit", "rules infraction" and "adjudication", so the other reasons are all "normal".
+func PGNTermination(t Termination) string {
+	switch t {
+	case NoTermination:
+		return "unterminated"
+	case IllegalMove:
+		return "rules infraction"
+	case Timeout:
+		return "time forfeit"
+	case Adjudication:
+		return "adjudication"
+	}
+	return "normal"
+}
+
+// terminationFromPGN returns the Termination for a PGN Termination tag value, given the result and final position of
+// the game. A "normal" ending is worked out from the final position if the game is over there, and is otherwise a
+// resignation (if decisive) or an agreed draw.
+func terminationFromPGN(tag string, res Result, final *Position) Termination {
+	switch strings.ToLower(tag) {
+	case "rules infraction":
+		return IllegalMove
+	case "time forfeit":
+		return Timeout
+	case "adjudication":
+		return Adjudication
+	case "unterminated":
+		return NoTermination
+	}
+	if res == InPlay {
+		return NoTermination
+	}
+	if finalRes, t := final.Outcome(); finalRes == res {
+		return t
+	}
+	if res == Draw {
+		return AgreedDraw
+	}
+	return Resignation
+}
+
+// PGNResult returns the PGN result token for a Result - "1-0", "0-1", "1/2-1/2", or "*" for a game in play.
+func PGNResult(res Result) string {
+	switch res {
+	case WhiteWin:
+		return "1-0"
+	case BlackWin:
+		return "0-1"
+	case Draw:
+		return "1/2-1/2"
+	}
+	return "*"
+}
+
+// resultFromPGN returns the Result for a PGN result token, and whether the token is a result token.
+func resultFromPGN(s string) (Result, bool) {
+	switch s {
+	case "1-0":
+		return WhiteWin, true
+	case "0-1":
+		return BlackWin, true
+	case "1/2-1/2":
+		return Draw, true
+	case "*":
+		return InPlay, true
+	}
+	return InPlay, false
+}
+
+// PGN returns the record written as PGN, with the moves in standard algebraic notation.
+func (r *Record) PGN() string {
+	tags := map[string]string{
+		"Event": "?", "Site": "?", "Date": "????.??.??", "Round": "?", "White": "?", "Black": "?",
+	}
+	for name, value := range r.Tags {
+		tags[name] = value
+	}
+	tags["Result"] = PGNResult(r.Result)
+	if r.Result != InPlay || r.Termination != NoTermination {
+		tags["Termination"] = PGNTermination(r.Termination)
+	}
+	rules := r.StartPos.Rules()
+	tags["Variant"] = rules.Name
+	delete(tags, "SetUp")
+	delete(tags, "FEN")
+	if startFEN := r.StartPos.FEN(); startFEN != rules.StartingFEN {
+		tags["SetUp"] = "1"
+		tags["FEN"] = startFEN
+	}
+
+	var sb strings.Builder
+	written := map[string]bool{}
+	for _, name := range pgnTagOrder {
+		if value, ok := tags[name]; ok {
+			writePGNTag(&sb, name, value)
+			written[name] = true
+		}
+	}
+	var others []string
+	for name := range tags {
+		if !written[name] {
+			others = append(others, name)
+		}
+	}
+	sort.Strings(others)
+	for _, name := range others {
+		writePGNTag(&sb, name, tags[name])
+	}
+	sb.WriteString("\n")
+
+	// movetext, wrapped to lines of at most 80 characters
+	var tokens []string
+	pos := r.StartPos.Copy()
+	for i, p := range r.Plies {
+		if pos.Turn == White {
+			tokens = append(tokens, fmt.Sprintf("%d.", pos.MoveNumber+1))
+		} else if i == 0 {
+			tokens = append(tokens, fmt.Sprintf("%d...", pos.MoveNumber+1))
+		}
+		tokens = append(tokens, pos.SAN(p))
+		if i < len(r.Comments) && r.Comments[i] != "" {
+			tokens = append(tokens, "{"+strings.ReplaceAll(r.Comments[i], "}", ")")+"}")
+		}
+		pos.UnsafeMove(p)
+	}
+	tokens = append(tokens, PGNResult(r.Result))
+
+	lineLen := 0
+	for _, tok := range tokens {
+		if lineLen > 0 && lineLen+1+len(tok) > 80 {
+			sb.WriteString("\n")
+			lineLen = 0
+		} else if lineLen > 0 {
+			sb.WriteString(" ")
+			lineLen++
+		}
+		sb.WriteString(tok)
+		lineLen += len(tok)
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+// writePGNTag writes a single tag pair, escaping quotes and backslashes in the value.
+func writePGNTag(sb *strings.Builder, name, value string) {
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, `"`, `\"`)
+	fmt.Fprintf(sb, "[%s \"%s\"]\n", name, value)
+}
+
+// WritePGN writes the record as PGN to w.
+func (r *Record) WritePGN(w io.Writer) error {
+	_, err := io.WriteString(w, r.PGN())
+	return err
+}
+
+// ReadPGN reads a single PGN game from rd. See ParsePGN for details.
+func ReadPGN(rd io.Reader) (*Record, error) {
+	b, err := ioutil.ReadAll(rd)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePGN(string(b))
+}
+
+// ParsePGN parses a single PGN game into a Record.
+// Every move is checked to be legal, so the record's FinalPosition can be used to continue the game.
+// Variations and NAGs are skipped, and only comments directly following a move are kept.
+func ParsePGN(pgn string) (*Record, error) {
+	r := &Record{Tags: map[string]string{}, Result: InPlay}
+
+	// tag pairs
+	s := strings.TrimSpace(pgn)
+	for strings.HasPrefix(s, "[") {
+		name, value, n, err := parsePGNTag(s)
+		if err != nil {
+			return nil, err
+		}
+		r.Tags[name] = value
+		s = strings.TrimSpace(s[n:])
+	}
+
+	variant := LosAlamos
+	if name, ok := r.Tags["Variant"]; ok {
+		if variant = VariantByName(name); variant == nil {
+			return nil, fmt.Errorf("pgn: unsupported variant %q", name)
+		}
+	}
+
+	startPos := NewVariantGamePosition(variant)
+	if fen, ok := r.Tags["FEN"]; ok {
+		var err error
+		startPos, err = PositionFromFEN(fen)
+		if err != nil {
+			return nil, fmt.Errorf("pgn: invalid FEN tag: %v", err)
+		}
+		startPos.Variant = variant
+	}
+	r.StartPos = startPos.Copy()
+
+	// movetext
+	pos := startPos
+	for len(s) > 0 {
+		switch c := s[0]; {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			s = s[1:]
+		case c == '{':
+			end := strings.IndexByte(s, '}')
+			if end < 0 {
+				return nil, fmt.Errorf("pgn: unterminated comment")
+			}
+			if len(r.Plies) > 0 {
+				comment := strings.TrimSpace(s[1:end])
+				if r.Comments[len(r.Plies)-1] != "" {
+					comment = r.Comments[len(r.Plies)-1] + " " + comment
+				}
+				r.Comments[len(r.Plies)-1] = comment
+			}
+			s = s[end+1:]
+		case c == ';':
+			end := strings.IndexByte(s, '\n')
+			if end < 0 {
+				end = len(s) - 1
+			}
+			s = s[end+1:]
+		case c == '(':
+			// skip the variation, which may itself contain variations
+			depth := 0
+			i := 0
+			for ; i < len(s); i++ {
+				if s[i] == '(' {
+					depth++
+				} else if s[i] == ')' {
+					depth--
+					if depth == 0 {
+						break
+					}
+				}
+			}
+			if depth != 0 {
+				return nil, fmt.Errorf("pgn: unterminated variation")
+			}
+			s = s[i+1:]
+		default:
+			end := strings.IndexAny(s, " \t\r\n{;(")
+			if end < 0 {
+				end = len(s)
+			}
+			tok := s[:end]
+			s = s[end:]
+
+			if res, ok := resultFromPGN(tok); ok {
+				r.Result = res
+				s = ""
+				break
+			}
+			// skip NAGs and move numbers like "12." or "12..."
+			if tok[0] == '$' {
+				continue
+			}
+			tok = strings.TrimLeft(tok, "0123456789")
+			tok = strings.TrimLeft(tok, ".")
+			if tok == "" {
+				continue
+			}
+
+			p, err := pos.ParseSAN(tok)
+			if err != nil {
+				return nil, fmt.Errorf("pgn: move %d (%s to move): %v", pos.MoveNumber+1, pos.Turn, err)
+			}
+			pos.UnsafeMove(p)
+			r.AddPly(p, "")
+		}
+	}
+
+	if res, ok := resultFromPGN(r.Tags["Result"]); ok && r.Result == InPlay {
+		r.Result = res
+	}
+	r.Termination = terminationFromPGN(r.Tags["Termination"], r.Result, pos)
+	return r, nil
+}
+
+// parsePGNTag parses the tag pair, such as `[White "Neo"]`, at the start of s, returning its name, value and length.
+func parsePGNTag(s string) (string, string, int, error) {
+	line := s
+	if end := strings.IndexByte(s, '\n'); end >= 0 {
+		line = s[:end]
+	}
+	open := strings.IndexByte(s, '"')
+	if open < 0 {
+		return "", "", 0, fmt.Errorf("pgn: malformed tag pair %q", line)
+	}
+	name := strings.TrimSpace(s[1:open])
+	if name == "" || strings.ContainsAny(name, " \t\n]") {
+		return "", "", 0, fmt.Errorf("pgn: malformed tag pair %q", line)
+	}
+
+	var value strings.Builder
+	for i := open + 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			if i+1 < len(s) {
+				i++
+				value.WriteByte(s[i])
+			}
+		case '"':
+			rest := strings.TrimLeft(s[i+1:], " \t")
+			if !strings.HasPrefix(rest, "]") {
+				return "", "", 0, fmt.Errorf("pgn: malformed tag pair %q", line)
+			}
+			return name, value.String(), len(s) - len(rest) + 1, nil
+		case '\n':
+			return "", "", 0, fmt.Errorf("pgn: unterminated tag value in %q", line)
+		default:
+			value.WriteByte(s[i])
+		}
+	}
+	return "", "", 0, fmt.Errorf("pgn: unterminated tag value in %q", line)
+}
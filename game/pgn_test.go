@@ -0,0 +1,165 @@
+package game
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// testPly returns the ply moving the piece on from to to in the position, a capture if to is occupied.
+func testPly(pos *Position, from, to Square) *Ply {
+	return &Ply{SourceSq: from, DestinationSq: to, Capture: pos.Bd.Piece(to) != NoPiece, Side: pos.Turn}
+}
+
+// TestTerminationRoundTrip plays a game to each way of ending it, checking that Outcome gives the termination for
+// those decided by the final position, and that the result and termination survive writing and reading the PGN.
+func TestTerminationRoundTrip(t *testing.T) {
+	sq := GetSquare
+	kingsAndRook := map[Square]Piece{
+		sq(FileE, Rank1): WhiteKing, sq(FileE, lastRank): BlackKing, sq(FileA, Rank2): WhiteRook,
+	}
+	// the kings step aside and back, so the position after the first ply recurs after the fifth and ninth
+	shuffle := [][2]Square{{sq(FileE, Rank1), sq(FileD, Rank1)}}
+	for i := 0; i < 2; i++ {
+		shuffle = append(shuffle,
+			[2]Square{sq(FileE, lastRank), sq(FileD, lastRank)}, [2]Square{sq(FileD, Rank1), sq(FileE, Rank1)},
+			[2]Square{sq(FileD, lastRank), sq(FileE, lastRank)}, [2]Square{sq(FileE, Rank1), sq(FileD, Rank1)})
+	}
+
+	tests := []struct {
+		name         string
+		pieces       map[Square]Piece
+		noProgress   uint
+		moves        [][2]Square
+		result       Result
+		termination  Termination
+		fromPosition bool // whether Outcome gives the result
+	}{
+		{
+			"checkmate",
+			map[Square]Piece{sq(FileB, lastRank-2): WhiteKing, sq(FileA, lastRank): BlackKing, sq(FileE, Rank1): WhiteRook},
+			0, [][2]Square{{sq(FileE, Rank1), sq(FileE, lastRank)}}, WhiteWin, Checkmate, true,
+		},
+		{
+			"stalemate",
+			map[Square]Piece{sq(FileE, Rank1): WhiteKing, sq(FileA, lastRank): BlackKing, sq(FileB, Rank1): WhiteQueen},
+			0, [][2]Square{{sq(FileB, Rank1), sq(FileB, lastRank-2)}}, Draw, Stalemate, true,
+		},
+		{"repetition", kingsAndRook, 0, shuffle, Draw, ThreefoldRepetition, true},
+		{
+			"insufficient material",
+			map[Square]Piece{sq(FileE, Rank1): WhiteKing, sq(FileE, lastRank): BlackKing, sq(FileC, Rank3): WhiteKnight,
+				sq(FileB, Rank5): BlackRook},
+			0, [][2]Square{{sq(FileC, Rank3), sq(FileB, Rank5)}}, Draw, InsufficientMaterial, true,
+		},
+		{
+			"no progress",
+			kingsAndRook, LosAlamos.NoProgressLimit - 1,
+			[][2]Square{{sq(FileE, Rank1), sq(FileD, Rank1)}}, Draw, MoveLimit, true,
+		},
+		{"illegal move", kingsAndRook, 0, shuffle[:1], BlackWin, IllegalMove, false},
+		{"timeout", kingsAndRook, 0, shuffle[:2], BlackWin, Timeout, false},
+		{"resignation", kingsAndRook, 0, shuffle[:3], WhiteWin, Resignation, false},
+		{"agreed draw", kingsAndRook, 0, shuffle[:2], Draw, AgreedDraw, false},
+		{"adjudication", kingsAndRook, 0, nil, WhiteWin, Adjudication, false},
+	}
+	for _, test := range tests {
+		pb := NewPositionBuilder().Clocks(0, test.noProgress)
+		for s, p := range test.pieces {
+			pb.Place(p, s)
+		}
+		start, err := pb.Build()
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+
+		r := NewRecord(start)
+		pos := start.Copy()
+		for _, m := range test.moves {
+			p := testPly(pos, m[0], m[1])
+			if err := pos.ApplyMove(p); err != nil {
+				t.Fatalf("%s: %v", test.name, err)
+			}
+			r.AddPly(p, "")
+		}
+		res, term := pos.Outcome()
+		if test.fromPosition && (res != test.result || term != test.termination) {
+			t.Errorf("%s: Outcome() = %s, %s, want %s, %s", test.name, res, term, test.result, test.termination)
+		}
+		if !test.fromPosition && term != NoTermination {
+			t.Errorf("%s: Outcome() = %s, %s, want the game in play", test.name, res, term)
+		}
+		r.Result, r.Termination = test.result, test.termination
+
+		read, err := ParsePGN(r.PGN())
+		if err != nil {
+			t.Fatalf("%s: ParsePGN(%q): %v", test.name, r.PGN(), err)
+		}
+		if read.Result != test.result || read.Termination != test.termination {
+			t.Errorf("%s: read back as %s, %s from\n%s", test.name, read.Result, read.Termination, r.PGN())
+		}
+		if got := read.FinalPosition().FEN(); got != pos.FEN() {
+			t.Errorf("%s: read back to %s, want %s", test.name, got, pos.FEN())
+		}
+	}
+}
+
+// TestPGNRoundTrip plays random games, from the starting position and from set up positions with either side to move,
+// checking that their PGN reads back to the same tags, plies, comments and final position.
+func TestPGNRoundTrip(t *testing.T) {
+	setUp, err := sanTestPosition(t).Edit().SideToMove(Black).Clocks(11, 3).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	starts := []*Position{NewGamePosition(), sanTestPosition(t), setUp}
+
+	rng := rand.New(rand.NewSource(1))
+	for g := 0; g < 30; g++ {
+		start := starts[g%len(starts)]
+		r := NewRecord(start)
+		r.Tags["White"] = "Neo"
+		r.Tags["Event"] = `Test "round trip"`
+		pos := start.Copy()
+		for i := 0; i < 150 && pos.Result() == InPlay; i++ {
+			moves := pos.GenerateLegalMoves()
+			p := moves[rng.Intn(len(moves))]
+			comment := ""
+			if i%7 == 0 {
+				comment = "ply " + p.String()
+			}
+			r.AddPly(p, comment)
+			pos.UnsafeMove(p)
+		}
+		r.Result, r.Termination = pos.Outcome()
+
+		pgn := r.PGN()
+		if fenTag := strings.Contains(pgn, "[SetUp \"1\"]"); fenTag != (start != starts[0]) {
+			t.Errorf("game %d: SetUp tag written %v, from %s", g, fenTag, start.FEN())
+		}
+		read, err := ParsePGN(pgn)
+		if err != nil {
+			t.Fatalf("game %d: ParsePGN: %v\n%s", g, err, pgn)
+		}
+		if len(read.Plies) != len(r.Plies) {
+			t.Fatalf("game %d: read back %d plies, want %d\n%s", g, len(read.Plies), len(r.Plies), pgn)
+		}
+		for i, p := range r.Plies {
+			if !read.Plies[i].Equals(p) || read.Comments[i] != r.Comments[i] {
+				t.Errorf("game %d: ply %d read back as %v {%s}, want %v {%s}", g, i, read.Plies[i], read.Comments[i], p,
+					r.Comments[i])
+			}
+		}
+		if read.StartPos.FEN() != start.FEN() || read.FinalPosition().FEN() != pos.FEN() {
+			t.Errorf("game %d: read back from %s to %s, want from %s to %s", g, read.StartPos.FEN(),
+				read.FinalPosition().FEN(), start.FEN(), pos.FEN())
+		}
+		if read.Result != r.Result || read.Termination != r.Termination {
+			t.Errorf("game %d: read back as %s, %s, want %s, %s", g, read.Result, read.Termination, r.Result, r.Termination)
+		}
+		for _, tag := range []string{"White", "Event"} {
+			if read.Tags[tag] != r.Tags[tag] {
+				t.Errorf("game %d: tag %s read back as %q, want %q", g, tag, read.Tags[tag], r.Tags[tag])
+			}
+		}
+	}
+}
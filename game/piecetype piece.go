@@ -17,6 +17,8 @@ func (p PieceType) String() string {
 		return "Queen"
 	case King:
 		return "King"
+	case Bishop:
+		return "Bishop"
 	}
 	return ""
 }
@@ -34,6 +36,8 @@ func PieceTypeFromString(s string) PieceType {
 		return Queen
 	case "King":
 		return King
+	case "Bishop":
+		return Bishop
 	}
 	return NoPieceType
 }
@@ -52,20 +56,19 @@ const (
 	Queen
 	// King represents a king
 	King
+	// Bishop represents a bishop, which Los Alamos chess does not have but other minichess variants do
+	Bishop
+
+	// numPieceTypes is the number of PieceTypes, including NoPieceType
+	numPieceTypes = iota
 )
 
 var (
-	// PromotionPieceTypes is a slice of the piece types that pawns may promote to
-	PromotionPieceTypes = []PieceType{
-		Knight,
-		Rook,
-		Queen,
-	}
-
-	// AllPieceTypes is a slice of the piece types that pawns may promote to
+	// AllPieceTypes is a slice of all the piece types, in increasing order of value
 	AllPieceTypes = []PieceType{
 		Pawn,
 		Knight,
+		Bishop,
 		Rook,
 		Queen,
 		King,
@@ -74,7 +77,7 @@ var (
 
 //--------------------------------------------------------------------------------
 
-// Piece is one of the 10 PieceType and Color combinations.
+// Piece is one of the 12 PieceType and Color combinations.
 type Piece int8
 
 // PieceType gets the PieceType
@@ -90,6 +93,8 @@ func (p Piece) PieceType() PieceType {
 		return Queen
 	case BlackKing, WhiteKing:
 		return King
+	case BlackBishop, WhiteBishop:
+		return Bishop
 	}
 	return NoPieceType
 }
@@ -107,6 +112,8 @@ func (p Piece) String() string {
 		return "White Queen"
 	case WhiteKing:
 		return "White King"
+	case WhiteBishop:
+		return "White Bishop"
 
 	case BlackPawn:
 		return "Black Pawn"
@@ -118,6 +125,8 @@ func (p Piece) String() string {
 		return "Black Queen"
 	case BlackKing:
 		return "Black King"
+	case BlackBishop:
+		return "Black Bishop"
 	}
 	return "NoPieceType"
 }
@@ -136,6 +145,8 @@ func NewPiece(pt PieceType, c Color) Piece {
 			return WhiteQueen
 		case King:
 			return WhiteKing
+		case Bishop:
+			return WhiteBishop
 		}
 	}
 	switch pt {
@@ -149,6 +160,8 @@ func NewPiece(pt PieceType, c Color) Piece {
 		return BlackQueen
 	case King:
 		return BlackKing
+	case Bishop:
+		return BlackBishop
 	}
 	return NoPiece
 }
@@ -156,9 +169,9 @@ func NewPiece(pt PieceType, c Color) Piece {
 // Color gets the Color
 func (p Piece) Color() Color {
 	switch p {
-	case WhiteKing, WhiteQueen, WhiteRook, WhiteKnight, WhitePawn:
+	case WhiteKing, WhiteQueen, WhiteRook, WhiteBishop, WhiteKnight, WhitePawn:
 		return White
-	case BlackKing, BlackQueen, BlackRook, BlackKnight, BlackPawn:
+	case BlackKing, BlackQueen, BlackRook, BlackBishop, BlackKnight, BlackPawn:
 		return Black
 	}
 	return NoColor
@@ -189,6 +202,11 @@ const (
 	BlackQueen
 	// BlackKing is a black king.
 	BlackKing
+
+	// WhiteBishop is a white bishop - numbered after the Los Alamos pieces, so their numbering is unchanged.
+	WhiteBishop
+	// BlackBishop is a black bishop.
+	BlackBishop
 )
 
 var (
@@ -196,6 +214,7 @@ var (
 	AllPieces = []Piece{
 		WhitePawn, WhiteKnight, WhiteRook, WhiteQueen, WhiteKing,
 		BlackPawn, BlackKnight, BlackRook, BlackQueen, BlackKing,
+		WhiteBishop, BlackBishop,
 	}
 
 	// PieceSymbMap is a mapping from Pieces to their Unicode symbols.
@@ -207,12 +226,14 @@ var (
 		WhiteRook:   "♖",
 		WhiteQueen:  "♕",
 		WhiteKing:   "♔",
+		WhiteBishop: "♗",
 
 		BlackPawn:   "♟",
 		BlackKnight: "♞",
 		BlackRook:   "♜",
 		BlackQueen:  "♛",
 		BlackKing:   "♚",
+		BlackBishop: "♝",
 	}
 
 	// PieceCharMap is a mapping from Pieces to their FEN characters (e.g. White Rook = 'R').
@@ -224,11 +245,13 @@ var (
 		WhiteRook:   "R",
 		WhiteQueen:  "Q",
 		WhiteKing:   "K",
+		WhiteBishop: "B",
 
 		BlackPawn:   "ℼ",
 		BlackKnight: "n",
 		BlackRook:   "r",
 		BlackQueen:  "q",
 		BlackKing:   "k",
+		BlackBishop: "b",
 	}
 )
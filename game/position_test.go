@@ -0,0 +1,185 @@
+package game
+
+import "testing"
+
+// positionState is what UnmakeMove must restore.
+type positionState struct {
+	fen           string
+	hash          uint64
+	hashList      []uint64
+	inCheck       bool
+	halfMoveClock uint
+	moveNumber    uint
+}
+
+func stateOf(pos *Position) positionState {
+	return positionState{
+		fen:           pos.FEN(),
+		hash:          pos.Hash,
+		hashList:      append([]uint64{}, pos.HashList...),
+		inCheck:       pos.InCheck,
+		halfMoveClock: pos.HalfMoveClock,
+		moveNumber:    pos.MoveNumber,
+	}
+}
+
+func (s positionState) equal(o positionState) bool {
+	if s.fen != o.fen || s.hash != o.hash || s.inCheck != o.inCheck || s.halfMoveClock != o.halfMoveClock ||
+		s.moveNumber != o.moveNumber || len(s.hashList) != len(o.hashList) {
+		return false
+	}
+	for i := range s.hashList {
+		if s.hashList[i] != o.hashList[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// TestMakeUnmakeMove checks that UnmakeMove restores the board, turn, clocks, check flag, hash and hash history
+// after every legal move in positions reached from the perft positions and in random games, including captures and
+// promotions.
+func TestMakeUnmakeMove(t *testing.T) {
+	var captures, promotions int
+	roundTrip := func(pos *Position, m Move) {
+		before := stateOf(pos)
+		u := pos.MakeCompactMove(m)
+		pos.UnmakeMove(u)
+		if after := stateOf(pos); !after.equal(before) {
+			t.Fatalf("after making and taking back %v: %+v, want %+v", m, after, before)
+		}
+		if m.Capture() {
+			captures++
+		}
+		if m.Promotion() != NoPieceType {
+			promotions++
+		}
+	}
+
+	var walk func(pos *Position, depth int)
+	walk = func(pos *Position, depth int) {
+		var ml MoveList
+		pos.GenerateLegalMoveList(&ml)
+		for _, m := range ml.Slice() {
+			roundTrip(pos, m)
+			if depth > 0 {
+				u := pos.MakeCompactMove(m)
+				walk(pos, depth-1)
+				pos.UnmakeMove(u)
+			}
+		}
+	}
+	for _, pr := range PerftResults {
+		pos, err := PositionFromFEN(pr.FEN)
+		if err != nil {
+			t.Fatalf("%s: %v", pr.Name, err)
+		}
+		walk(pos, 2)
+	}
+
+	// random games reach promotions, and longer hash histories
+	playRandomGames(t, 100, 200, func(pos *Position, _ Move) {
+		var ml MoveList
+		pos.GenerateLegalMoveList(&ml)
+		for _, m := range ml.Slice() {
+			roundTrip(pos, m)
+		}
+	})
+	if captures == 0 || promotions == 0 {
+		t.Errorf("took back %d captures and %d promotions", captures, promotions)
+	}
+}
+
+// TestNoProgressRule checks that HalfMoveClock counts the half moves since the last capture or pawn move, and that the
+// game is drawn once it reaches the variant's NoProgressLimit - unless the limit is 0.
+func TestNoProgressRule(t *testing.T) {
+	sq := GetSquare
+	build := func(v *Variant, halfMoveClock uint) *Position {
+		pos, err := NewPositionBuilder().
+			Place(WhiteKing, sq(FileE, Rank1)).Place(BlackKing, sq(FileE, lastRank)).
+			Place(WhiteRook, sq(FileA, Rank1)).Place(WhitePawn, sq(FileB, Rank2)).
+			Place(BlackKnight, sq(FileA, lastRank)).
+			Clocks(0, halfMoveClock).Variant(v).Build()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return pos
+	}
+	play := func(pos *Position, from, to Square) {
+		if err := pos.ApplyMove(testPly(pos, from, to)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	pos := build(nil, 10)
+	play(pos, sq(FileE, Rank1), sq(FileD, Rank1))
+	if pos.HalfMoveClock != 11 {
+		t.Errorf("after a king move, HalfMoveClock = %d, want 11", pos.HalfMoveClock)
+	}
+	knight := sq(FileC, lastRank-1)
+	play(pos, sq(FileA, lastRank), knight)
+	play(pos, sq(FileB, Rank2), sq(FileB, Rank3))
+	if pos.HalfMoveClock != 0 {
+		t.Errorf("after a pawn move, HalfMoveClock = %d, want 0", pos.HalfMoveClock)
+	}
+	play(pos, sq(FileE, lastRank), sq(FileD, lastRank))
+	play(pos, sq(FileA, Rank1), sq(FileA, knight.Rank()))
+	if pos.HalfMoveClock != 2 {
+		t.Errorf("after two quiet moves, HalfMoveClock = %d, want 2", pos.HalfMoveClock)
+	}
+	play(pos, sq(FileD, lastRank), sq(FileE, lastRank))
+	play(pos, sq(FileA, knight.Rank()), knight)
+	if pos.HalfMoveClock != 0 {
+		t.Errorf("after a capture, HalfMoveClock = %d, want 0", pos.HalfMoveClock)
+	}
+
+	limit := LosAlamos.NoProgressLimit
+	pos = build(nil, limit-2)
+	play(pos, sq(FileE, Rank1), sq(FileD, Rank1))
+	if res, term := pos.Outcome(); term != NoTermination {
+		t.Errorf("at %d half moves without progress, Outcome() = %s, %s, want the game in play", limit-1, res, term)
+	}
+	play(pos, sq(FileE, lastRank), sq(FileD, lastRank))
+	if res, term := pos.Outcome(); res != Draw || term != MoveLimit {
+		t.Errorf("at %d half moves without progress, Outcome() = %s, %s, want %s, %s", limit, res, term, Result(Draw), MoveLimit)
+	}
+
+	noLimit := *LosAlamos
+	noLimit.NoProgressLimit = 0
+	pos = build(&noLimit, 10*limit)
+	play(pos, sq(FileE, Rank1), sq(FileD, Rank1))
+	if res, term := pos.Outcome(); term != NoTermination {
+		t.Errorf("with NoProgressLimit 0, Outcome() = %s, %s, want the game in play", res, term)
+	}
+}
+
+// TestRepetitionWindow checks that repetitions are only looked for among the last HalfMoveClock+1 entries of HashList -
+// in a game, the current position and those since the last capture or pawn move.
+func TestRepetitionWindow(t *testing.T) {
+	pos := NewGamePosition()
+	h := pos.Hash
+	other := h + 1
+	tests := []struct {
+		history       []uint64
+		halfMoveClock uint
+		want          bool
+	}{
+		{[]uint64{h, h, h}, 2, true},
+		{[]uint64{h, h, h}, 1, false},
+		{[]uint64{h, h, h}, 0, false},
+		{[]uint64{h, other, h, other, h}, 4, true},
+		{[]uint64{h, other, h, other, h}, 3, false},
+		{[]uint64{h, h, other, other}, 3, false},
+		{[]uint64{h, h, other, other}, 100, false},
+	}
+	for _, test := range tests {
+		pos, err := pos.Edit().History(test.history...).Clocks(0, test.halfMoveClock).Build()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, term := pos.Outcome(); (term == ThreefoldRepetition) != test.want {
+			t.Errorf("history %v, HalfMoveClock %d: Outcome() termination %s, want repetition %v",
+				test.history, test.halfMoveClock, term, test.want)
+		}
+	}
+}
@@ -1,9 +1,9 @@
 package game
 
-// File is one of the 6 columns of the board (e.g. the 'a' File)
+// File is one of the columns of the board (e.g. the 'a' File) - 6 on the Los Alamos board
 type File int8
 
-const fileChars string = "abcdef"
+const fileChars string = "abcdefgh"
 
 // String gives a string representation of the file - e.g. 'a' file
 // Implements the fmt.Stringer interface.
@@ -11,7 +11,7 @@ func (f File) String() string {
 	return fileChars[f : f+1]
 }
 
-// Enumerating all Files on the board
+// Enumerating all Files on the board, up to the h File on an 8x8 board
 const (
 	FileA File = iota
 	FileB
@@ -19,14 +19,16 @@ const (
 	FileD
 	FileE
 	FileF
+	FileG
+	FileH
 )
 
 //--------------------------------------------------------------------------------
 
-// Rank is one of the 6 rows of the board (e.g. '1st' Rank)
+// Rank is one of the rows of the board (e.g. '1st' Rank) - 6 on the Los Alamos board
 type Rank int8
 
-const rankChars string = "123456"
+const rankChars string = "12345678"
 
 // String gives a string representation of the rank - e.g. rank '1'
 // Implements the fmt.Stringer interface.
@@ -34,7 +36,7 @@ func (r Rank) String() string {
 	return rankChars[r : r+1]
 }
 
-// Enumerating all 6 Ranks on the board
+// Enumerating all Ranks on the board, up to the 8th on an 8x8 board
 const (
 	Rank1 Rank = iota
 	Rank2
@@ -42,4 +44,6 @@ const (
 	Rank4
 	Rank5
 	Rank6
+	Rank7
+	Rank8
 )
@@ -0,0 +1,164 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+)
+
+var (
+	// sanPieceChars is a mapping from PieceTypes to their letters in standard algebraic notation (pawns have none).
+	sanPieceChars = map[PieceType]string{
+		Pawn:   "",
+		Knight: "N",
+		Rook:   "R",
+		Queen:  "Q",
+		King:   "K",
+		Bishop: "B",
+	}
+
+	// sanCharPieceTypes is a mapping from standard algebraic notation letters to PieceTypes.
+	sanCharPieceTypes = map[byte]PieceType{
+		'N': Knight,
+		'R': Rook,
+		'Q': Queen,
+		'K': King,
+		'B': Bishop,
+	}
+)
+
+// SAN returns the standard algebraic notation for a legal ply in this position - e.g. "Nc3", "exd6=Q", "Qxc5+" or "Rf4#".
+// Moves are disambiguated using the other legal moves in the position.
+func (pos *Position) SAN(p *Ply) string {
+	var sb strings.Builder
+	pt := pos.Bd.Piece(p.SourceSq).PieceType()
+
+	if pt == Pawn {
+		if p.Capture {
+			sb.WriteString(p.SourceSq.File().String())
+		}
+	} else {
+		sb.WriteString(sanPieceChars[pt])
+		sb.WriteString(pos.sanDisambiguation(p, pt))
+	}
+
+	if p.Capture {
+		sb.WriteString("x")
+	}
+	sb.WriteString(p.DestinationSq.String())
+
+	if p.Promotion != NoPieceType {
+		sb.WriteString("=" + sanPieceChars[p.Promotion])
+	}
+
+	newPos := pos.Copy()
+	newPos.UnsafeMove(p)
+	if newPos.InCheck {
+		if newPos.GenerateCountOfLegalMoves() == 0 {
+			sb.WriteString("#")
+		} else {
+			sb.WriteString("+")
+		}
+	}
+	return sb.String()
+}
+
+// sanDisambiguation returns the source file, rank or square needed to distinguish p from other legal moves of the same
+// piece type to the same destination square.
+func (pos *Position) sanDisambiguation(p *Ply, pt PieceType) string {
+	var ambiguous, sameFile, sameRank bool
+	for _, lgm := range pos.GenerateLegalMoves() {
+		if lgm.SourceSq == p.SourceSq || lgm.DestinationSq != p.DestinationSq || pos.Bd.Piece(lgm.SourceSq).PieceType() != pt {
+			continue
+		}
+		ambiguous = true
+		sameFile = sameFile || lgm.SourceSq.File() == p.SourceSq.File()
+		sameRank = sameRank || lgm.SourceSq.Rank() == p.SourceSq.Rank()
+	}
+
+	switch {
+	case !ambiguous:
+		return ""
+	case !sameFile:
+		return p.SourceSq.File().String()
+	case !sameRank:
+		return p.SourceSq.Rank().String()
+	}
+	return p.SourceSq.String()
+}
+
+// ParseSAN returns the legal ply in this position described by a move in standard algebraic notation.
+// Check, checkmate and annotation suffixes are ignored, and the promotion '=' may be left out (e.g. "e6Q").
+func (pos *Position) ParseSAN(san string) (*Ply, error) {
+	s := strings.TrimRight(strings.TrimSpace(san), "+#!?")
+	if len(s) < 2 {
+		return nil, fmt.Errorf("san: move %q is too short", san)
+	}
+
+	// promotion
+	promotion := NoPieceType
+	if pt, ok := sanCharPieceTypes[s[len(s)-1]]; ok {
+		if pt == King {
+			return nil, fmt.Errorf("san: cannot promote to a King in %q", san)
+		}
+		promotion = pt
+		s = strings.TrimSuffix(s[:len(s)-1], "=")
+	}
+
+	// moving piece
+	pt := Pawn
+	if p, ok := sanCharPieceTypes[s[0]]; ok {
+		pt = p
+		s = s[1:]
+	}
+
+	// destination square
+	if len(s) < 2 {
+		return nil, fmt.Errorf("san: no destination square in %q", san)
+	}
+	dest, ok := StringToSquareMap[s[len(s)-2:]]
+	if !ok {
+		return nil, fmt.Errorf("san: invalid destination square %q in %q", s[len(s)-2:], san)
+	}
+	s = s[:len(s)-2]
+
+	// capture marker
+	capture := strings.HasSuffix(s, "x")
+	s = strings.TrimSuffix(s, "x")
+
+	// disambiguation - any of file, rank or both
+	var fromFile, fromRank = -1, -1
+	if len(s) > 2 {
+		return nil, fmt.Errorf("san: invalid move %q", san)
+	}
+	for i := 0; i < len(s); i++ {
+		switch {
+		case strings.IndexByte(fileChars, s[i]) >= 0 && fromFile < 0 && fromRank < 0:
+			fromFile = strings.IndexByte(fileChars, s[i])
+		case strings.IndexByte(rankChars, s[i]) >= 0 && fromRank < 0:
+			fromRank = strings.IndexByte(rankChars, s[i])
+		default:
+			return nil, fmt.Errorf("san: invalid source disambiguation %q in %q", s, san)
+		}
+	}
+
+	var matches []*Ply
+	for _, lgm := range pos.GenerateLegalMoves() {
+		if lgm.DestinationSq != dest || lgm.Promotion != promotion || pos.Bd.Piece(lgm.SourceSq).PieceType() != pt {
+			continue
+		}
+		if (fromFile >= 0 && int(lgm.SourceSq.File()) != fromFile) || (fromRank >= 0 && int(lgm.SourceSq.Rank()) != fromRank) {
+			continue
+		}
+		matches = append(matches, lgm)
+	}
+
+	switch {
+	case len(matches) == 0:
+		return nil, fmt.Errorf("san: no legal move matches %q", san)
+	case len(matches) > 1:
+		return nil, fmt.Errorf("san: move %q is ambiguous, %d legal moves match", san, len(matches))
+	case capture && !matches[0].Capture:
+		return nil, fmt.Errorf("san: move %q is marked as a capture but %s is empty", san, dest)
+	}
+	return matches[0], nil
+}
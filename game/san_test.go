@@ -0,0 +1,144 @@
+package game
+
+import (
+	"strings"
+	"testing"
+)
+
+// sanTestPosition returns a position, fitting in the corner of every board size, where two pairs of White's rooks can
+// reach the same square and a pawn can promote with or without capturing.
+func sanTestPosition(t *testing.T) *Position {
+	sq := GetSquare
+	pos, err := NewPositionBuilder().
+		Place(WhiteKing, sq(FileE, Rank1)).Place(BlackKing, sq(FileE, lastRank)).
+		Place(WhiteRook, sq(FileA, Rank1), sq(FileA, Rank3), sq(FileC, Rank3)).
+		Place(WhitePawn, sq(FileB, lastRank-1)).
+		Place(BlackKnight, sq(FileC, lastRank)).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pos
+}
+
+// TestSAN checks the notation SAN gives to moves needing disambiguation, promotions and checks, and that ParseSAN
+// reads it back.
+func TestSAN(t *testing.T) {
+	sq := GetSquare
+	last := lastRank.String()
+	pos := sanTestPosition(t)
+	mate, err := NewPositionBuilder().
+		Place(WhiteKing, sq(FileB, lastRank-2)).Place(BlackKing, sq(FileA, lastRank)).Place(WhiteRook, sq(FileE, Rank1)).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		pos *Position
+		ply Ply
+		san string
+	}{
+		{pos, Ply{SourceSq: sq(FileA, Rank1), DestinationSq: sq(FileA, Rank2)}, "R1a2"},
+		{pos, Ply{SourceSq: sq(FileA, Rank3), DestinationSq: sq(FileA, Rank2)}, "R3a2"},
+		{pos, Ply{SourceSq: sq(FileA, Rank3), DestinationSq: sq(FileB, Rank3)}, "Rab3"},
+		{pos, Ply{SourceSq: sq(FileC, Rank3), DestinationSq: sq(FileB, Rank3)}, "Rcb3"},
+		{pos, Ply{SourceSq: sq(FileA, Rank1), DestinationSq: sq(FileB, Rank1)}, "Rb1"},
+		{pos, Ply{SourceSq: sq(FileE, Rank1), DestinationSq: sq(FileD, Rank1)}, "Kd1"},
+		{pos, Ply{SourceSq: sq(FileC, Rank3), DestinationSq: sq(FileC, lastRank), Capture: true}, "Rxc" + last + "+"},
+		{pos, Ply{SourceSq: sq(FileB, lastRank-1), DestinationSq: sq(FileB, lastRank), Promotion: Queen}, "b" + last + "=Q"},
+		{
+			pos,
+			Ply{SourceSq: sq(FileB, lastRank-1), DestinationSq: sq(FileC, lastRank), Promotion: Rook, Capture: true},
+			"bxc" + last + "=R+",
+		},
+		{mate, Ply{SourceSq: sq(FileE, Rank1), DestinationSq: sq(FileE, lastRank)}, "Re" + last + "#"},
+	}
+	for _, test := range tests {
+		test.ply.Side = test.pos.Turn
+		if got := test.pos.SAN(&test.ply); got != test.san {
+			t.Errorf("SAN(%v) = %q, want %q", &test.ply, got, test.san)
+		}
+		p, err := test.pos.ParseSAN(test.san)
+		if err != nil || !p.Equals(&test.ply) {
+			t.Errorf("ParseSAN(%q) = %v, %v, want %v", test.san, p, err, &test.ply)
+		}
+	}
+}
+
+// TestParseSAN checks the other ways of writing moves ParseSAN accepts, and that it rejects malformed, ambiguous and
+// illegal moves.
+func TestParseSAN(t *testing.T) {
+	sq := GetSquare
+	last := lastRank.String()
+	pos := sanTestPosition(t)
+	valid := []struct {
+		san string
+		ply Ply
+	}{
+		{"Ra1a2", Ply{SourceSq: sq(FileA, Rank1), DestinationSq: sq(FileA, Rank2)}},
+		{"R3a2", Ply{SourceSq: sq(FileA, Rank3), DestinationSq: sq(FileA, Rank2)}},
+		{"b" + last + "Q", Ply{SourceSq: sq(FileB, lastRank-1), DestinationSq: sq(FileB, lastRank), Promotion: Queen}},
+		{"Rxc" + last + "+!?", Ply{SourceSq: sq(FileC, Rank3), DestinationSq: sq(FileC, lastRank), Capture: true}},
+		{" Kd1 ", Ply{SourceSq: sq(FileE, Rank1), DestinationSq: sq(FileD, Rank1)}},
+	}
+	for _, test := range valid {
+		test.ply.Side = White
+		if p, err := pos.ParseSAN(test.san); err != nil || !p.Equals(&test.ply) {
+			t.Errorf("ParseSAN(%q) = %v, %v, want %v", test.san, p, err, &test.ply)
+		}
+	}
+
+	invalid := []struct {
+		san  string
+		want string
+	}{
+		{"", "too short"},
+		{"R", "too short"},
+		{"Rz9", "invalid destination square"},
+		{"Ra", "no destination square"},
+		{"Rzb3", "invalid source disambiguation"},
+		{"Ra1a3b3", "invalid move"},
+		{"Ra2", "ambiguous"},
+		{"Rb3", "ambiguous"},
+		{"R3b3", "ambiguous"},
+		{"Raxb3", "marked as a capture"},
+		{"Qd1", "no legal move"},
+		{"Rd4", "no legal move"},
+		{"b" + last, "no legal move"},
+		{"b" + last + "=K", "cannot promote to a King"},
+		{"Kxd1", "marked as a capture"},
+	}
+	for _, test := range invalid {
+		if p, err := pos.ParseSAN(test.san); err == nil || !strings.Contains(err.Error(), test.want) {
+			t.Errorf("ParseSAN(%q) = %v, %v, want an error containing %q", test.san, p, err, test.want)
+		}
+	}
+}
+
+// TestSANRoundTrip checks that ParseSAN reads back the notation SAN gives every legal move of positions reached from
+// the perft positions.
+func TestSANRoundTrip(t *testing.T) {
+	var walk func(pos *Position, depth int)
+	walk = func(pos *Position, depth int) {
+		for _, p := range pos.GenerateLegalMoves() {
+			san := pos.SAN(p)
+			if got, err := pos.ParseSAN(san); err != nil || !got.Equals(p) {
+				t.Fatalf("%s: ParseSAN(%q) = %v, %v, want %v", pos.FEN(), san, got, err, p)
+			}
+			if depth > 0 {
+				next := pos.Copy()
+				next.UnsafeMove(p)
+				walk(next, depth-1)
+			}
+		}
+	}
+	for _, pr := range PerftResults {
+		pos, err := PositionFromFEN(pr.FEN)
+		if err != nil {
+			t.Fatalf("%s: %v", pr.Name, err)
+		}
+		walk(pos, 1)
+	}
+	walk(sanTestPosition(t), 1)
+}
@@ -0,0 +1,74 @@
+package game
+
+import "math/bits"
+
+// maxExchange bounds the length of a capture sequence on one square - there are at most 32 pieces on the board (24 on
+// the 6x6 board).
+const maxExchange = 32
+
+// SEE (static exchange evaluation) returns the material balance for the side making the capture p after both sides
+// recapture on the destination square with their least valuable attacker for as long as it gains them material.
+// Attackers revealed behind sliding pieces as pieces are exchanged (x-rays) join in, pawns recapturing onto the last
+// rank promote to a queen, and the king only recaptures if the square is no longer attacked.
+// Pieces are valued by weights, e.g. an Evaluator's MaterialWeights. A non-capture returns the material gained by
+// a promotion, less the piece lost if the destination square is attacked.
+func (b *Board) SEE(p *Ply, weights map[PieceType]float32) float32 {
+	var value [numPieceTypes]float32
+	for _, pt := range AllPieceTypes {
+		value[pt] = weights[pt]
+	}
+
+	b.UpdateConvenienceBBs()
+	dst := p.DestinationSq
+	occupied := (b.wPieces | b.bPieces) &^ p.SourceSq.BitBoard()
+
+	var gain [maxExchange]float32
+	gain[0] = value[b.Piece(dst).PieceType()]
+	onSquare := b.Piece(p.SourceSq).PieceType()
+	if p.Promotion != NoPieceType {
+		gain[0] += value[p.Promotion] - value[Pawn]
+		onSquare = p.Promotion
+	}
+
+	side := p.Side.Other()
+	d := 0
+	for d+1 < maxExchange {
+		attackers := b.AttackersTo(dst, occupied) & occupied
+		ours := attackers & b.PieceOccupancy(side)
+		if ours == 0 {
+			break
+		}
+		sq, pt := b.leastValuableAttacker(ours, side)
+		if pt == King && attackers&b.PieceOccupancy(side.Other()) != 0 {
+			break
+		}
+		d++
+		gain[d] = value[onSquare] - gain[d-1]
+		onSquare = pt
+		if pt == Pawn && (dst.Rank() == Rank1 || dst.Rank() == lastRank) {
+			gain[d] += value[Queen] - value[Pawn]
+			onSquare = Queen
+		}
+		occupied &^= sq.BitBoard()
+		side = side.Other()
+	}
+
+	// each side may stop capturing when continuing loses material
+	for ; d > 0; d-- {
+		if gain[d] > -gain[d-1] {
+			gain[d-1] = -gain[d]
+		}
+	}
+	return gain[0]
+}
+
+// leastValuableAttacker returns the square and type of the least valuable of side's pieces in attackers, which must
+// not be empty.
+func (b *Board) leastValuableAttacker(attackers BitBoard, side Color) (Square, PieceType) {
+	for _, pt := range AllPieceTypes {
+		if bb := attackers & b.BitBoardForPiece(NewPiece(pt, side)); bb != 0 {
+			return Square(bits.TrailingZeros64(uint64(bb))), pt
+		}
+	}
+	panic("No attacker on board")
+}
@@ -0,0 +1,87 @@
+package game
+
+import "testing"
+
+// TestSEE checks the exchanges SEE plays out on boards which fit in the corner of every board size.
+func TestSEE(t *testing.T) {
+	weights := map[PieceType]float32{Pawn: 1, Knight: 3, Bishop: 3, Rook: 5, Queen: 9}
+	sq := GetSquare
+	tests := []struct {
+		name   string
+		pieces map[Square]Piece
+		ply    Ply
+		want   float32
+	}{
+		{
+			"undefended capture",
+			map[Square]Piece{sq(FileA, Rank1): WhiteKing, sq(FileE, Rank5): BlackKing,
+				sq(FileC, Rank1): WhiteRook, sq(FileC, Rank4): BlackKnight},
+			Ply{SourceSq: sq(FileC, Rank1), DestinationSq: sq(FileC, Rank4), Capture: true, Side: White},
+			3,
+		},
+		{
+			"capture defended by a pawn",
+			map[Square]Piece{sq(FileA, Rank1): WhiteKing, sq(FileE, Rank5): BlackKing,
+				sq(FileC, Rank1): WhiteRook, sq(FileC, Rank3): BlackKnight, sq(FileD, Rank4): BlackPawn},
+			Ply{SourceSq: sq(FileC, Rank1), DestinationSq: sq(FileC, Rank3), Capture: true, Side: White},
+			3 - 5,
+		},
+		{
+			// without the queen behind the rook, the knight would cost the rook
+			"x-ray queen behind a rook",
+			map[Square]Piece{sq(FileA, Rank1): WhiteKing, sq(FileE, Rank5): BlackKing,
+				sq(FileC, Rank1): WhiteQueen, sq(FileC, Rank2): WhiteRook, sq(FileC, Rank4): BlackKnight,
+				sq(FileC, Rank5): BlackRook},
+			Ply{SourceSq: sq(FileC, Rank2), DestinationSq: sq(FileC, Rank4), Capture: true, Side: White},
+			3 - 5 + 5,
+		},
+		{
+			"x-ray rook behind a rook",
+			map[Square]Piece{sq(FileA, Rank1): WhiteKing, sq(FileE, Rank5): BlackKing,
+				sq(FileC, Rank1): WhiteRook, sq(FileC, Rank2): WhiteRook, sq(FileC, Rank4): BlackQueen,
+				sq(FileC, Rank5): BlackRook},
+			Ply{SourceSq: sq(FileC, Rank2), DestinationSq: sq(FileC, Rank4), Capture: true, Side: White},
+			9 - 5 + 5,
+		},
+		{
+			// the pawn recapturing on the first rank becomes a queen
+			"recapture with promotion",
+			map[Square]Piece{sq(FileE, Rank3): WhiteKing, sq(FileA, Rank5): BlackKing,
+				sq(FileC, Rank5): WhiteRook, sq(FileC, Rank1): BlackKnight, sq(FileB, Rank2): BlackPawn},
+			Ply{SourceSq: sq(FileC, Rank5), DestinationSq: sq(FileC, Rank1), Capture: true, Side: White},
+			3 - 5 - (9 - 1),
+		},
+		{
+			"capture with promotion",
+			map[Square]Piece{sq(FileE, Rank3): WhiteKing, sq(FileA, Rank5): BlackKing,
+				sq(FileC, Rank2): BlackPawn, sq(FileB, Rank1): WhiteRook},
+			Ply{SourceSq: sq(FileC, Rank2), DestinationSq: sq(FileB, Rank1), Promotion: Queen, Capture: true, Side: Black},
+			5 + 9 - 1,
+		},
+		{
+			"king recaptures an undefended piece",
+			map[Square]Piece{sq(FileA, Rank1): WhiteKing, sq(FileD, Rank5): BlackKing,
+				sq(FileC, Rank1): WhiteRook, sq(FileC, Rank4): BlackKnight},
+			Ply{SourceSq: sq(FileC, Rank1), DestinationSq: sq(FileC, Rank4), Capture: true, Side: White},
+			3 - 5,
+		},
+		{
+			// the king may not recapture onto a square the knight attacks
+			"king does not recapture a defended piece",
+			map[Square]Piece{sq(FileA, Rank1): WhiteKing, sq(FileD, Rank5): BlackKing,
+				sq(FileC, Rank1): WhiteRook, sq(FileC, Rank4): BlackKnight, sq(FileB, Rank2): WhiteKnight},
+			Ply{SourceSq: sq(FileC, Rank1), DestinationSq: sq(FileC, Rank4), Capture: true, Side: White},
+			3,
+		},
+	}
+	for _, test := range tests {
+		b := BoardFromMap(test.pieces)
+		before := b.FEN()
+		if got := b.SEE(&test.ply, weights); got != test.want {
+			t.Errorf("%s: SEE(%v) = %v, want %v", test.name, &test.ply, got, test.want)
+		}
+		if b.FEN() != before {
+			t.Errorf("%s: SEE changed the board", test.name)
+		}
+	}
+}
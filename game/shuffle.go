@@ -0,0 +1,103 @@
+package game
+
+import "strings"
+
+// Shuffled starts (as in Chess960) rearrange the pieces on White's back rank of StartingFEN, with Black's back rank
+// mirroring White's and the pawns where they were. Without castling any arrangement is allowed, so the 6x6 board has
+// 6!/(2!2!) = 180 of them.
+//
+// Arrangements are numbered from 0 to NumShuffledStarts-1 in lexicographic order of their PieceTypes from the a file,
+// an empty square being NoPieceType. The standard R N Q K N R arrangement is #76 on the 6x6 board.
+
+var (
+	// shuffledPieceCounts is the number of each type of piece (NoPieceType for empty squares) on the back rank
+	shuffledPieceCounts = startingBackRankCounts()
+
+	// NumShuffledStarts is the number of different shuffled starts
+	NumShuffledStarts = arrangements(shuffledPieceCounts)
+)
+
+// startingBackRankCounts returns the number of each type of piece on White's back rank in StartingFEN.
+func startingBackRankCounts() [numPieceTypes]int {
+	var counts [numPieceTypes]int
+	bd, err := BoardFromFEN(strings.Fields(StartingFEN)[0])
+	if err != nil {
+		panic("Invalid starting position: " + err.Error())
+	}
+	for f := FileA; f < numSquaresInRow; f++ {
+		counts[bd.Piece(GetSquare(f, Rank1)).PieceType()]++
+	}
+	return counts
+}
+
+// arrangements returns the number of different orders of a row holding counts[pt] pieces of each type pt.
+func arrangements(counts [numPieceTypes]int) int {
+	n, total := 1, 0
+	for _, c := range counts {
+		// multiply in the ways to choose the c squares out of the total so far, one at a time
+		for i := 1; i <= c; i++ {
+			total++
+			n = n * total / i
+		}
+	}
+	return n
+}
+
+// ShuffledStart returns the position at the start of a game from shuffled start #n, panicking if n is not between
+// 0 and NumShuffledStarts-1.
+func ShuffledStart(n int) *Position {
+	if n < 0 || n >= NumShuffledStarts {
+		panic("Invalid shuffled start number")
+	}
+
+	start := NewGamePosition()
+	m := map[Square]Piece{}
+	for sq := Square(0); sq < numSquaresInBoard; sq++ {
+		m[sq] = start.Bd.Piece(sq)
+	}
+
+	counts := shuffledPieceCounts
+	for f := FileA; f < numSquaresInRow; f++ {
+		// the first piece type whose arrangements of the remaining squares reach past n goes on this square
+		for pt := NoPieceType; pt < numPieceTypes; pt++ {
+			if counts[pt] == 0 {
+				continue
+			}
+			counts[pt]--
+			if k := arrangements(counts); n >= k {
+				n -= k
+				counts[pt]++
+				continue
+			}
+			m[GetSquare(f, Rank1)] = NewPiece(pt, White)
+			m[GetSquare(f, lastRank)] = NewPiece(pt, Black)
+			break
+		}
+	}
+	return NewPosition(BoardFromMap(m), White, 0, 0, nil)
+}
+
+// ShuffledStartNumber returns the number of the shuffled start the board's back ranks are in, or -1 if they are not
+// a shuffled start (the other ranks are not checked).
+func ShuffledStartNumber(b *Board) int {
+	counts := shuffledPieceCounts
+	n := 0
+	for f := FileA; f < numSquaresInRow; f++ {
+		p := b.Piece(GetSquare(f, Rank1))
+		pt := p.PieceType()
+		if (p != NoPiece && p.Color() != White) || b.Piece(GetSquare(f, lastRank)) != NewPiece(pt, Black) ||
+			counts[pt] == 0 {
+			return -1
+		}
+		// count the arrangements with a lower piece type on this square
+		for lower := NoPieceType; lower < pt; lower++ {
+			if counts[lower] > 0 {
+				counts[lower]--
+				n += arrangements(counts)
+				counts[lower]++
+			}
+		}
+		counts[pt]--
+	}
+	return n
+}
@@ -0,0 +1,39 @@
+package game
+
+import "testing"
+
+// standardShuffledStarts is the number of the standard starting arrangement among the shuffled starts, by variant
+var standardShuffledStarts = map[string]int{
+	"losalamos":    76,
+	"gardner":      28,
+	"nobishops8x8": 2970,
+}
+
+// TestShuffledStartNumber checks that every shuffled start is numbered as it was built, and that the standard start
+// has its documented number.
+func TestShuffledStartNumber(t *testing.T) {
+	seen := map[string]bool{}
+	for n := 0; n < NumShuffledStarts; n++ {
+		pos := ShuffledStart(n)
+		if got := ShuffledStartNumber(pos.Bd); got != n {
+			t.Errorf("ShuffledStartNumber(ShuffledStart(%d)) = %d", n, got)
+		}
+		fen := pos.FEN()
+		if seen[fen] {
+			t.Errorf("ShuffledStart(%d) repeats an earlier start: %s", n, fen)
+		}
+		seen[fen] = true
+	}
+
+	start := NewGamePosition()
+	want, ok := standardShuffledStarts[start.Rules().Name]
+	if !ok {
+		t.Fatalf("no standard shuffled start number for variant %s", start.Rules().Name)
+	}
+	if got := ShuffledStartNumber(start.Bd); got != want {
+		t.Errorf("standard start is shuffled start #%d, want #%d", got, want)
+	}
+	if got := ShuffledStart(want).FEN(); got != StartingFEN {
+		t.Errorf("ShuffledStart(%d) = %s, want %s", want, got, StartingFEN)
+	}
+}
@@ -0,0 +1,158 @@
+package game
+
+import "fmt"
+
+// Sliding piece attacks are looked up in tables indexed by the occupancy of the squares the piece's rays pass through
+// (magic bitboards). For each square, the relevant occupancy - the ray squares excluding the board edge, since a piece
+// there cannot block anything further - is multiplied by a magic number whose top bits then give a unique index.
+// The tables are generated at startup, so a 6x6 board needs only a few thousand entries.
+
+// magic holds the lookup parameters for sliding attacks from one square.
+type magic struct {
+	mask    BitBoard
+	magic   uint64
+	shift   uint
+	attacks []BitBoard
+}
+
+var (
+	rookMagics     [numSquaresInBoard]magic
+	diagonalMagics [numSquaresInBoard]magic
+
+	// bbBetween[a][b] is the squares strictly between a and b if they share a rank, file or diagonal, else empty
+	bbBetween [numSquaresInBoard][numSquaresInBoard]BitBoard
+
+	rookDirections     = [][2]int{{1, 0}, {-1, 0}, {0, 1}, {0, -1}}
+	diagonalDirections = [][2]int{{1, 1}, {1, -1}, {-1, 1}, {-1, -1}}
+)
+
+func init() {
+	// fixed seed, so the tables are the same on every run
+	rng := xorshift(1070372)
+	for sq := 0; sq < numSquaresInBoard; sq++ {
+		rookMagics[sq] = findMagic(Square(sq), rookDirections, &rng)
+		diagonalMagics[sq] = findMagic(Square(sq), diagonalDirections, &rng)
+	}
+	for a := 0; a < numSquaresInBoard; a++ {
+		for b := 0; b < numSquaresInBoard; b++ {
+			occ := Square(a).BitBoard() | Square(b).BitBoard()
+			for _, dirs := range [][][2]int{rookDirections, diagonalDirections} {
+				if slidingAttacks(Square(a), occ, dirs).Occupied(Square(b)) {
+					bbBetween[a][b] = slidingAttacks(Square(a), occ, dirs) & slidingAttacks(Square(b), occ, dirs)
+				}
+			}
+		}
+	}
+}
+
+// Between returns the squares strictly between a and b if they share a rank, file or diagonal, otherwise an empty
+// bitboard.
+func Between(a, b Square) BitBoard {
+	return bbBetween[a][b]
+}
+
+// RookAttacks returns the squares attacked by a rook on sq, given the occupied squares (of either color).
+func RookAttacks(sq Square, occupied BitBoard) BitBoard {
+	m := &rookMagics[sq]
+	return m.attacks[(uint64(occupied&m.mask)*m.magic)>>m.shift]
+}
+
+// DiagonalAttacks returns the squares attacked along the diagonals and anti-diagonals from sq, given the occupied squares.
+func DiagonalAttacks(sq Square, occupied BitBoard) BitBoard {
+	m := &diagonalMagics[sq]
+	return m.attacks[(uint64(occupied&m.mask)*m.magic)>>m.shift]
+}
+
+// QueenAttacks returns the squares attacked by a queen on sq, given the occupied squares (of either color).
+func QueenAttacks(sq Square, occupied BitBoard) BitBoard {
+	return RookAttacks(sq, occupied) | DiagonalAttacks(sq, occupied)
+}
+
+// slidingAttacks walks each ray from sq until it leaves the board or hits an occupied square (which is included).
+func slidingAttacks(sq Square, occupied BitBoard, directions [][2]int) BitBoard {
+	var attacks BitBoard
+	for _, d := range directions {
+		f, r := int(sq.File())+d[0], int(sq.Rank())+d[1]
+		for f >= 0 && f < numSquaresInRow && r >= 0 && r < numSquaresInRow {
+			s := GetSquare(File(f), Rank(r))
+			attacks |= s.BitBoard()
+			if occupied.Occupied(s) {
+				break
+			}
+			f, r = f+d[0], r+d[1]
+		}
+	}
+	return attacks
+}
+
+// relevantOccupancy returns the ray squares from sq that can block a slider, i.e. excluding the last square of each ray.
+func relevantOccupancy(sq Square, directions [][2]int) BitBoard {
+	var mask BitBoard
+	for _, d := range directions {
+		f, r := int(sq.File())+d[0], int(sq.Rank())+d[1]
+		for f+d[0] >= 0 && f+d[0] < numSquaresInRow && r+d[1] >= 0 && r+d[1] < numSquaresInRow {
+			mask |= GetSquare(File(f), Rank(r)).BitBoard()
+			f, r = f+d[0], r+d[1]
+		}
+	}
+	return mask
+}
+
+// findMagic searches for a magic number that maps every subset of the relevant occupancy of sq to an attack table
+// index without destructive collisions, and returns it with the filled table.
+func findMagic(sq Square, directions [][2]int, rng *xorshift) magic {
+	mask := relevantOccupancy(sq, directions)
+	bits := uint(0)
+	for b := mask; b != 0; b &= b - 1 {
+		bits++
+	}
+
+	// enumerate all subsets of the mask (Carry-Rippler) with their attacks
+	var occupancies, attacks []BitBoard
+	for sub := BitBoard(0); ; {
+		occupancies = append(occupancies, sub)
+		attacks = append(attacks, slidingAttacks(sq, sub, directions))
+		sub = (sub - mask) & mask
+		if sub == 0 {
+			break
+		}
+	}
+
+	m := magic{mask: mask, shift: 64 - bits, attacks: make([]BitBoard, 1<<bits)}
+	used := make([]bool, 1<<bits)
+	for tries := 0; tries < 100000000; tries++ {
+		m.magic = rng.sparse()
+		for i := range used {
+			used[i] = false
+		}
+		ok := true
+		for i, occ := range occupancies {
+			idx := (uint64(occ) * m.magic) >> m.shift
+			if used[idx] && m.attacks[idx] != attacks[i] {
+				ok = false
+				break
+			}
+			used[idx] = true
+			m.attacks[idx] = attacks[i]
+		}
+		if ok {
+			return m
+		}
+	}
+	panic(fmt.Sprintf("No magic found for square %s", sq))
+}
+
+// xorshift is a small deterministic random number generator for the magic search.
+type xorshift uint64
+
+func (x *xorshift) next() uint64 {
+	*x ^= *x >> 12
+	*x ^= *x << 25
+	*x ^= *x >> 27
+	return uint64(*x) * 2685821657736338717
+}
+
+// sparse returns a random number with few bits set, which makes a good magic candidate.
+func (x *xorshift) sparse() uint64 {
+	return x.next() & x.next() & x.next()
+}
@@ -0,0 +1,62 @@
+package game
+
+import "testing"
+
+// TestSlidingAttacks checks the magic bitboard tables against CalcLinearAttack for every square and every occupancy
+// of the squares a slider's rays pass through, both with and without the squares at the ends of the rays occupied.
+func TestSlidingAttacks(t *testing.T) {
+	for sq := Square(0); sq < numSquaresInBoard; sq++ {
+		sqbb := sq.BitBoard()
+		for _, slider := range []struct {
+			name       string
+			directions [][2]int
+			lookup     func(Square, BitBoard) BitBoard
+			lines      []BitBoard
+		}{
+			{"rook", rookDirections, RookAttacks, []BitBoard{sq.FileBB(), sq.RankBB()}},
+			{"diagonal", diagonalDirections, DiagonalAttacks, []BitBoard{BBDiagonals[sq], BBAntiDiagonals[sq]}},
+		} {
+			mask := relevantOccupancy(sq, slider.directions)
+			rayEnds := slidingAttacks(sq, 0, slider.directions) &^ mask
+			for sub := BitBoard(0); ; {
+				for _, occ := range []BitBoard{sub, sub | rayEnds} {
+					// the lines only share the slider's square, which is not attacked along any of them
+					var want BitBoard
+					for _, line := range slider.lines {
+						want ^= CalcLinearAttack(occ|sqbb, line, sqbb)
+					}
+					if got := slider.lookup(sq, occ); got != want {
+						t.Fatalf("%s attacks from %s with occupancy %x: got %x, want %x", slider.name, sq, occ, got, want)
+					}
+				}
+				sub = (sub - mask) & mask
+				if sub == 0 {
+					break
+				}
+			}
+		}
+	}
+}
+
+func BenchmarkQueenAttacks(b *testing.B) {
+	occupied := startingOccupancy()
+	for i := 0; i < b.N; i++ {
+		_ = QueenAttacks(Square(i%numSquaresInBoard), occupied)
+	}
+}
+
+// BenchmarkLinearQueenAttacks times the queen attacks the magic bitboard tables replaced.
+func BenchmarkLinearQueenAttacks(b *testing.B) {
+	occupied := startingOccupancy()
+	for i := 0; i < b.N; i++ {
+		sq := Square(i % numSquaresInBoard)
+		sqbb := sq.BitBoard()
+		_ = CalcLinearAttack(occupied, sq.FileBB(), sqbb) ^ CalcLinearAttack(occupied, sq.RankBB(), sqbb) ^
+			CalcLinearAttack(occupied, BBDiagonals[sq], sqbb) ^ CalcLinearAttack(occupied, BBAntiDiagonals[sq], sqbb)
+	}
+}
+
+func startingOccupancy() BitBoard {
+	pos := NewGamePosition()
+	return pos.Bd.PieceOccupancy(White) | pos.Bd.PieceOccupancy(Black)
+}
@@ -0,0 +1,225 @@
+package game
+
+import (
+	"fmt"
+	"math/bits"
+	"strings"
+)
+
+// ValidationProblem identifies a way in which a Board or Position cannot arise in a game.
+type ValidationProblem uint8
+
+const (
+	// MissingKing - a side has no king
+	MissingKing ValidationProblem = iota + 1
+	// DuplicateKing - a side has more than one king
+	DuplicateKing
+	// PawnOnBackRank - a pawn stands on the 1st or last rank, where it would have promoted (or could never have been)
+	PawnOnBackRank
+	// OverlappingPieces - a square is occupied by more than one piece
+	OverlappingPieces
+	// OutsideBoard - a piece bitboard has bits set outside BBMask
+	OutsideBoard
+	// OpponentInCheck - the side which just moved left its king in check
+	OpponentInCheck
+	// ImpossiblePieceCount - a side has more pieces than it could have with its original pieces and promotions
+	ImpossiblePieceCount
+)
+
+// String returns a description of the problem.
+// Implements the fmt.Stringer interface.
+func (vp ValidationProblem) String() string {
+	switch vp {
+	case MissingKing:
+		return "missing king"
+	case DuplicateKing:
+		return "more than one king"
+	case PawnOnBackRank:
+		return "pawn on 1st or last rank"
+	case OverlappingPieces:
+		return "more than one piece on a square"
+	case OutsideBoard:
+		return "piece outside the board"
+	case OpponentInCheck:
+		return "side not to move is in check"
+	case ImpossiblePieceCount:
+		return "impossible piece count"
+	}
+	return "unknown problem"
+}
+
+// ValidationError is a single problem found by Validate.
+type ValidationError struct {
+	Problem ValidationProblem
+	// Color is the side the problem concerns, NoColor if it concerns neither
+	Color Color
+	// Squares are the squares concerned, if any
+	Squares BitBoard
+}
+
+// Error implements the error interface.
+func (e *ValidationError) Error() string {
+	var sb strings.Builder
+	sb.WriteString(e.Problem.String())
+	if e.Color != NoColor {
+		fmt.Fprintf(&sb, " (%s)", e.Color)
+	}
+	if sqs := e.Squares & BBMask; sqs != 0 {
+		sb.WriteString(" on")
+		for ; sqs != 0; sqs &= sqs - 1 {
+			fmt.Fprintf(&sb, " %s", Square(bits.TrailingZeros64(uint64(sqs))))
+		}
+	}
+	return sb.String()
+}
+
+// ValidationErrors is every problem found by Validate, in the order they were checked.
+type ValidationErrors []*ValidationError
+
+// Error implements the error interface.
+func (ve ValidationErrors) Error() string {
+	msgs := make([]string, len(ve))
+	for i, e := range ve {
+		msgs[i] = e.Error()
+	}
+	return "invalid position: " + strings.Join(msgs, "; ")
+}
+
+// Has returns whether any of the errors is the problem vp.
+func (ve ValidationErrors) Has(vp ValidationProblem) bool {
+	for _, e := range ve {
+		if e.Problem == vp {
+			return true
+		}
+	}
+	return false
+}
+
+//--------------------------------------------------------------------------------
+
+// Validate checks that the board could arise in a game of LosAlamos, returning ValidationErrors listing every problem,
+// or nil.
+func (b *Board) Validate() error {
+	return b.validate(LosAlamos.PromotionPieceTypes)
+}
+
+// validate is Validate for a game in which pawns promote to the piece types promotions.
+func (b *Board) validate(promotions []PieceType) error {
+	var ve ValidationErrors
+
+	var outside, occupied, overlap BitBoard
+	for _, p := range AllPieces {
+		bb := b.BitBoardForPiece(p)
+		outside |= bb &^ BBMask
+		overlap |= occupied & bb
+		occupied |= bb
+	}
+	if outside != 0 {
+		ve = append(ve, &ValidationError{Problem: OutsideBoard, Color: NoColor})
+	}
+	if overlap != 0 {
+		ve = append(ve, &ValidationError{Problem: OverlappingPieces, Color: NoColor, Squares: overlap})
+	}
+
+	for _, c := range []Color{White, Black} {
+		kings := b.BitBoardForPiece(NewPiece(King, c)) & BBMask
+		switch bits.OnesCount64(uint64(kings)) {
+		case 0:
+			ve = append(ve, &ValidationError{Problem: MissingKing, Color: c})
+		case 1:
+		default:
+			ve = append(ve, &ValidationError{Problem: DuplicateKing, Color: c, Squares: kings})
+		}
+
+		pawns := b.BitBoardForPiece(NewPiece(Pawn, c)) & BBMask
+		if backRank := pawns & (BBRanks[Rank1] | BBRanks[lastRank]); backRank != 0 {
+			ve = append(ve, &ValidationError{Problem: PawnOnBackRank, Color: c, Squares: backRank})
+		}
+
+		if !b.possiblePieceCount(c, promotions) {
+			ve = append(ve, &ValidationError{Problem: ImpossiblePieceCount, Color: c})
+		}
+	}
+
+	if len(ve) == 0 {
+		return nil
+	}
+	return ve
+}
+
+// possiblePieceCount returns whether c's pieces could be its original pieces, less captures, plus pawns promoted to
+// the piece types promotions.
+func (b *Board) possiblePieceCount(c Color, promotions []PieceType) bool {
+	count := func(pt PieceType) int {
+		return bits.OnesCount64(uint64(b.BitBoardForPiece(NewPiece(pt, c)) & BBMask))
+	}
+	pawns := count(Pawn)
+	promoted := 0
+	for _, pt := range []PieceType{Knight, Bishop, Rook, Queen} {
+		extra := count(pt) - originalPieceCounts[pt]
+		if extra <= 0 {
+			continue
+		}
+		if !containsPieceType(promotions, pt) {
+			return false
+		}
+		promoted += extra
+	}
+	return pawns <= NumSquaresInRow && promoted <= NumSquaresInRow-pawns
+}
+
+// Validate checks that the position could arise in a game of its variant, returning ValidationErrors listing every
+// problem with the board and the side to move, or nil.
+func (pos *Position) Validate() error {
+	var ve ValidationErrors
+	if err := pos.Bd.validate(pos.Rules().PromotionPieceTypes); err != nil {
+		ve = err.(ValidationErrors)
+	}
+	// the check test needs exactly one king on each side
+	if !ve.Has(MissingKing) && !ve.Has(DuplicateKing) && !ve.Has(OutsideBoard) {
+		other := pos.Turn.Other()
+		if pos.Bd.InCheck(other) {
+			ve = append(ve, &ValidationError{
+				Problem: OpponentInCheck,
+				Color:   other,
+				Squares: pos.Bd.BitBoardForPiece(NewPiece(King, other)),
+			})
+		}
+	}
+	if len(ve) == 0 {
+		return nil
+	}
+	return ve
+}
+
+// ValidBoardFromMap is BoardFromMap, returning an error from Validate if the board could not arise in a game.
+func ValidBoardFromMap(m map[Square]Piece) (*Board, error) {
+	b := BoardFromMap(m)
+	if err := b.Validate(); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// NewValidPosition is NewPosition, returning an error from Validate if the position could not arise in a game.
+// The board is validated before the position is set up, so a board without kings is reported rather than panicking.
+func NewValidPosition(bd *Board, turn Color, moveNumber, halfMoveClock uint, hashlist []uint64) (*Position, error) {
+	return newValidPosition(bd, turn, moveNumber, halfMoveClock, hashlist, nil)
+}
+
+// newValidPosition is NewValidPosition for a position played under the variant v (LosAlamos if nil).
+func newValidPosition(bd *Board, turn Color, moveNumber, halfMoveClock uint, hashlist []uint64, v *Variant) (*Position, error) {
+	rules := v
+	if rules == nil {
+		rules = LosAlamos
+	}
+	if err := bd.validate(rules.PromotionPieceTypes); err != nil {
+		return nil, err
+	}
+	pos := NewPosition(bd, turn, moveNumber, halfMoveClock, hashlist)
+	pos.Variant = v
+	if err := pos.Validate(); err != nil {
+		return nil, err
+	}
+	return pos, nil
+}
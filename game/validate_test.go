@@ -0,0 +1,124 @@
+package game
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestValidate checks that Validate reports each problem, and only that problem, on a board which has it.
+func TestValidate(t *testing.T) {
+	sq := GetSquare
+	fullRank := func(r Rank, p Piece) map[Square]Piece {
+		m := map[Square]Piece{}
+		for f := FileA; f < numSquaresInRow; f++ {
+			m[sq(f, r)] = p
+		}
+		return m
+	}
+	withPieces := func(m map[Square]Piece, extra map[Square]Piece) map[Square]Piece {
+		for s, p := range extra {
+			m[s] = p
+		}
+		return m
+	}
+	kings := func(extra map[Square]Piece) map[Square]Piece {
+		return withPieces(map[Square]Piece{sq(FileA, Rank1): WhiteKing, sq(FileE, lastRank): BlackKing}, extra)
+	}
+
+	type validateTest struct {
+		name   string
+		board  *Board
+		turn   Color
+		want   ValidationProblem // 0 for a valid position
+		colour Color
+	}
+	tests := []validateTest{
+		{"two kings", BoardFromMap(kings(nil)), White, 0, NoColor},
+		{"no white king", BoardFromMap(map[Square]Piece{sq(FileE, lastRank): BlackKing}), White, MissingKing, White},
+		{"two black kings", BoardFromMap(kings(map[Square]Piece{sq(FileC, Rank3): BlackKing})), White, DuplicateKing, Black},
+		{"white pawn on the 1st rank", BoardFromMap(kings(map[Square]Piece{sq(FileC, Rank1): WhitePawn})), White, PawnOnBackRank, White},
+		{"black pawn on the last rank", BoardFromMap(kings(map[Square]Piece{sq(FileC, lastRank): BlackPawn})), White, PawnOnBackRank, Black},
+		{"white pawn on the last rank", BoardFromMap(kings(map[Square]Piece{sq(FileC, lastRank): WhitePawn})), Black, PawnOnBackRank, White},
+		{
+			"side not to move in check",
+			BoardFromMap(kings(map[Square]Piece{sq(FileE, Rank1): WhiteRook})), White, OpponentInCheck, Black,
+		},
+		{
+			"a full rank of pawns and a promoted knight",
+			BoardFromMap(withPieces(kings(fullRank(Rank2, WhitePawn)),
+				map[Square]Piece{sq(FileA, Rank3): WhiteKnight, sq(FileB, Rank3): WhiteKnight, sq(FileA, Rank4): WhiteKnight})),
+			White, ImpossiblePieceCount, White,
+		},
+		{
+			"too many pawns",
+			BoardFromMap(withPieces(kings(fullRank(lastRank-1, BlackPawn)), map[Square]Piece{sq(FileA, Rank3): BlackPawn})),
+			White, ImpossiblePieceCount, Black,
+		},
+	}
+
+	overlapping := BoardFromMap(kings(map[Square]Piece{sq(FileC, Rank3): BlackKnight}))
+	overlapping.SetBBForPiece(WhiteRook, sq(FileC, Rank3).BitBoard())
+	tests = append(tests, validateTest{"overlapping pieces", overlapping, White, OverlappingPieces, NoColor})
+
+	// an 8x8 board has no bits outside BBMask
+	if BBMask != ^BitBoard(0) {
+		outside := BoardFromMap(kings(nil))
+		mask := BBMask
+		outside.SetBBForPiece(WhiteRook, mask+1)
+		tests = append(tests, validateTest{"rook outside the board", outside, White, OutsideBoard, NoColor})
+	}
+
+	for _, test := range tests {
+		// not NewPosition, which needs both kings
+		pos := &Position{Bd: test.board, Turn: test.turn}
+		err := pos.Validate()
+		if test.want == 0 {
+			if err != nil {
+				t.Errorf("%s: Validate() = %v, want nil", test.name, err)
+			}
+			continue
+		}
+		var ve ValidationErrors
+		if !errors.As(err, &ve) || len(ve) != 1 || ve[0].Problem != test.want || ve[0].Color != test.colour {
+			t.Errorf("%s: Validate() = %v, want only %s (%s)", test.name, err, test.want, test.colour)
+		}
+	}
+}
+
+// TestValidatePromotionPieceTypes checks that a piece beyond a side's original pieces is only possible if pawns may
+// promote to its type.
+func TestValidatePromotionPieceTypes(t *testing.T) {
+	sq := GetSquare
+	pieces := func(pt PieceType) map[Square]Piece {
+		m := map[Square]Piece{sq(FileA, Rank1): WhiteKing, sq(FileE, lastRank): BlackKing}
+		// one more than the original pieces
+		for f := File(0); f <= File(originalPieceCounts[pt]); f++ {
+			m[sq(f, Rank2)] = NewPiece(pt, White)
+		}
+		return m
+	}
+
+	for _, pt := range []PieceType{Knight, Bishop, Rook, Queen} {
+		err := BoardFromMap(pieces(pt)).Validate()
+		if promotes := LosAlamos.isPromotionPieceType(pt); (err == nil) != promotes {
+			t.Errorf("with an extra %s, which pawns promote to: %v, Validate() = %v", pt, promotes, err)
+		}
+	}
+
+	queenOnly := &Variant{Name: "queenonly", PromotionPieceTypes: []PieceType{Queen}}
+	for _, pt := range []PieceType{Knight, Queen} {
+		// Black to move, as the pieces may give check
+		pb := NewPositionBuilder().Variant(queenOnly).SideToMove(Black)
+		for s, p := range pieces(pt) {
+			pb.Place(p, s)
+		}
+		_, err := pb.Build()
+		var ve ValidationErrors
+		switch {
+		case pt == Queen && err != nil:
+			t.Errorf("with an extra queen under %s: Build() = %v", queenOnly.Name, err)
+		case pt != Queen && (!errors.As(err, &ve) || !ve.Has(ImpossiblePieceCount)):
+			t.Errorf("with an extra %s under %s: Build() = %v, want %s", pt, queenOnly.Name, err, ImpossiblePieceCount)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package game
+
+import "strings"
+
+// Variant describes the rules which differ between minichess rule sets, so rule tweaks can be tried without changing
+// move generation. A Position plays by its Variant (LosAlamos if nil); the board size is fixed when building (see
+// geometry.go).
+type Variant struct {
+	// Name is used for the PGN Variant tag
+	Name string
+
+	// StartingFEN is the position a game starts from
+	StartingFEN string
+
+	// PromotionPieceTypes are the piece types a pawn reaching the 1st or last rank may promote to
+	PromotionPieceTypes []PieceType
+
+	// PawnDoubleStep allows pawns on their starting rank to move two squares forward if both are empty.
+	// There is no en passant capture.
+	PawnDoubleStep bool
+
+	// Stalemate is the result of a stalemate for the side which cannot move
+	Stalemate StalemateRule
+
+	// NoProgressLimit is the number of half moves without a capture or pawn move after which the game is drawn.
+	// 100 is the fifty-move rule; 0 disables the rule.
+	NoProgressLimit uint
+
+	// RepetitionLimit is the number of times a position must occur for the game to be drawn; 0 disables the rule.
+	RepetitionLimit uint
+
+	// InsufficientMaterialDraw draws the game when neither side has more than a king and a knight or bishop
+	InsufficientMaterialDraw bool
+}
+
+// StalemateRule is what a stalemate counts as for the side which cannot move
+type StalemateRule int8
+
+// Enumerating all possible StalemateRules
+const (
+	StalemateDraw StalemateRule = iota
+	StalemateLoss
+	StalemateWin
+)
+
+// stalemateResult returns the result of the game when stalemated cannot move.
+func (v *Variant) stalemateResult(stalemated Color) Result {
+	switch v.Stalemate {
+	case StalemateLoss:
+		return NewResultWin(stalemated.Other())
+	case StalemateWin:
+		return NewResultWin(stalemated)
+	}
+	return Draw
+}
+
+// isPromotionPieceType returns whether pawns may promote to pt.
+func (v *Variant) isPromotionPieceType(pt PieceType) bool {
+	return containsPieceType(v.PromotionPieceTypes, pt)
+}
+
+// containsPieceType returns whether pt is one of pts.
+func containsPieceType(pts []PieceType, pt PieceType) bool {
+	for _, p := range pts {
+		if p == pt {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	// LosAlamos is the standard rule set, and the rules of a Position without a Variant.
+	// It is Los Alamos chess on the 6x6 board, and the standard game of the board size on the others.
+	LosAlamos = &Variant{
+		Name:                     standardVariantName,
+		StartingFEN:              StartingFEN,
+		PromotionPieceTypes:      PromotionPieceTypes,
+		PawnDoubleStep:           standardPawnDoubleStep,
+		Stalemate:                StalemateDraw,
+		NoProgressLimit:          100,
+		RepetitionLimit:          3,
+		InsufficientMaterialDraw: true,
+	}
+
+	// LosAlamosStalemateLoss is Los Alamos chess where the stalemated side loses, as in some older rules of chess
+	LosAlamosStalemateLoss = &Variant{
+		Name:                     standardVariantName + "-stalemateloss",
+		StartingFEN:              StartingFEN,
+		PromotionPieceTypes:      PromotionPieceTypes,
+		PawnDoubleStep:           standardPawnDoubleStep,
+		Stalemate:                StalemateLoss,
+		NoProgressLimit:          100,
+		RepetitionLimit:          3,
+		InsufficientMaterialDraw: true,
+	}
+
+	// LosAlamosStalemateWin is Los Alamos chess where the stalemated side wins, as in English rules of the 18th century
+	LosAlamosStalemateWin = &Variant{
+		Name:                     standardVariantName + "-stalematewin",
+		StartingFEN:              StartingFEN,
+		PromotionPieceTypes:      PromotionPieceTypes,
+		PawnDoubleStep:           standardPawnDoubleStep,
+		Stalemate:                StalemateWin,
+		NoProgressLimit:          100,
+		RepetitionLimit:          3,
+		InsufficientMaterialDraw: true,
+	}
+
+	// LosAlamosDoubleStep is Los Alamos chess where pawns may move two squares from their starting rank
+	LosAlamosDoubleStep = &Variant{
+		Name:                     standardVariantName + "-doublestep",
+		StartingFEN:              StartingFEN,
+		PromotionPieceTypes:      PromotionPieceTypes,
+		PawnDoubleStep:           true,
+		Stalemate:                StalemateDraw,
+		NoProgressLimit:          100,
+		RepetitionLimit:          3,
+		InsufficientMaterialDraw: true,
+	}
+
+	// Variants are all the rule sets, looked up by VariantByName
+	Variants = []*Variant{LosAlamos, LosAlamosStalemateLoss, LosAlamosStalemateWin, LosAlamosDoubleStep}
+)
+
+// VariantByName returns the variant with the given name (ignoring case), or nil if there is none.
+func VariantByName(name string) *Variant {
+	for _, v := range Variants {
+		if strings.EqualFold(v.Name, name) {
+			return v
+		}
+	}
+	return nil
+}
+
+//--------------------------------------------------------------------------------
+
+// Rules returns the variant the position is played under.
+func (pos *Position) Rules() *Variant {
+	if pos.Variant == nil {
+		return LosAlamos
+	}
+	return pos.Variant
+}
+
+// NewVariantGamePosition returns a position at the start of a game of the variant.
+func NewVariantGamePosition(v *Variant) *Position {
+	pos, err := PositionFromFEN(v.StartingFEN)
+	if err != nil {
+		panic("Invalid starting position for variant " + v.Name + ": " + err.Error())
+	}
+	pos.Variant = v
+	return pos
+}
+
+// movesVector is MovesVector with the moves the variant adds.
+func (pos *Position) movesVector(sq Square) BitBoard {
+	mv := pos.Bd.MovesVector(sq)
+	if pos.Rules().PawnDoubleStep {
+		mv |= pos.Bd.pawnDoubleStep(sq)
+	}
+	return mv
+}
+
+// pawnDoubleStep returns the square two squares in front of a pawn on sq, if the pawn is on its starting rank and
+// both squares are empty.
+func (b *Board) pawnDoubleStep(sq Square) BitBoard {
+	var over, to Square
+	switch {
+	case b.wPawns.Occupied(sq) && sq.Rank() == Rank2:
+		over, to = GetSquare(sq.File(), Rank3), GetSquare(sq.File(), Rank4)
+	case b.bPawns.Occupied(sq) && sq.Rank() == lastRank-1:
+		over, to = GetSquare(sq.File(), lastRank-2), GetSquare(sq.File(), lastRank-3)
+	default:
+		return 0
+	}
+	if !b.emptySqs.Occupied(over) || !b.emptySqs.Occupied(to) {
+		return 0
+	}
+	return to.BitBoard()
+}
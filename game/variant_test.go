@@ -0,0 +1,94 @@
+package game
+
+import "testing"
+
+// TestStalemateRules checks the result Outcome gives for a stalemate under each StalemateRule.
+func TestStalemateRules(t *testing.T) {
+	tests := []struct {
+		variant *Variant
+		want    Result
+	}{
+		{LosAlamos, Draw},
+		{LosAlamosStalemateLoss, WhiteWin},
+		{LosAlamosStalemateWin, BlackWin},
+	}
+	for _, test := range tests {
+		// the queen covers every square the black king could move to, without attacking it
+		pos, err := NewPositionBuilder().
+			Place(BlackKing, GetSquare(FileA, lastRank)).
+			Place(WhiteQueen, GetSquare(FileB, lastRank-2)).
+			Place(WhiteKing, GetSquare(FileE, Rank1)).
+			SideToMove(Black).
+			Variant(test.variant).
+			Build()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res, term := pos.Outcome(); res != test.want || term != Stalemate {
+			t.Errorf("%s: Outcome() = %s, %s, want %s, %s", test.variant.Name, res, term, test.want, Stalemate)
+		}
+	}
+}
+
+// TestPawnDoubleStep checks that under LosAlamosDoubleStep a pawn on its starting rank may move two squares, unless
+// either square is occupied, and that the starting position's move counts include the double steps.
+func TestPawnDoubleStep(t *testing.T) {
+	sq := GetSquare
+	doubleSteps := func(pos *Position) map[Square]bool {
+		steps := map[Square]bool{}
+		var ml MoveList
+		pos.GenerateLegalMoveList(&ml)
+		for _, m := range ml.Slice() {
+			from, to := m.Source(), m.Destination()
+			if pos.Bd.Piece(from).PieceType() == Pawn && (to.Rank()-from.Rank() == 2 || from.Rank()-to.Rank() == 2) {
+				steps[from] = true
+			}
+		}
+		return steps
+	}
+	build := func(v *Variant, turn Color, pieces map[Square]Piece) *Position {
+		pb := NewPositionBuilder().SideToMove(turn).Variant(v)
+		for s, p := range pieces {
+			pb.Place(p, s)
+		}
+		pos, err := pb.Build()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return pos
+	}
+	pieces := map[Square]Piece{
+		sq(FileE, Rank1): WhiteKing, sq(FileE, lastRank): BlackKing,
+		sq(FileA, Rank2): WhitePawn,                                // free to move two squares
+		sq(FileB, Rank2): WhitePawn, sq(FileB, Rank3): BlackKnight, // blocked on the square it passes
+		sq(FileC, Rank2): WhitePawn, sq(FileC, Rank4): BlackKnight, // blocked on the square it reaches
+		sq(FileD, Rank3):      WhitePawn, // off its starting rank
+		sq(FileE, lastRank-1): BlackPawn,
+	}
+
+	for _, turn := range []Color{White, Black} {
+		want := sq(FileA, Rank2)
+		if turn == Black {
+			want = sq(FileE, lastRank-1)
+		}
+		if got := doubleSteps(build(LosAlamosDoubleStep, turn, pieces)); len(got) != 1 || !got[want] {
+			t.Errorf("%s to move: double steps from %v, want from %s only", turn, got, want)
+		}
+		if standard := doubleSteps(build(LosAlamos, turn, pieces)); !standardPawnDoubleStep && len(standard) != 0 {
+			t.Errorf("%s to move: %s has double steps from %v", turn, LosAlamos.Name, standard)
+		}
+	}
+
+	if NumSquaresInRow != 6 {
+		t.Skip("the starting position counts are for the 6x6 board")
+	}
+	// White has its 10 moves and 6 double steps. Black has as many, less the double step a white pawn or knight on the
+	// 3rd rank blocks, or for a double step to the 4th rank, the pawn's two moves on the file plus a capture of it by
+	// each neighbouring pawn: 6*15 + (2*15 + 4*16) + 4*15.
+	pos := NewVariantGamePosition(LosAlamosDoubleStep)
+	for depth, want := range []uint64{16, 244} {
+		if got := pos.Perft(uint(depth + 1)); got != want {
+			t.Errorf("Perft(%d) = %d, want %d", depth+1, got, want)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package game
+
+import "fmt"
+
+// numPieces is the number of Pieces, including NoPiece
+const numPieces = 13
+
+// DebugZobrist makes MakeMove (and so UnsafeMove) check that the incrementally updated hash equals a freshly
+// recomputed one, panicking if not.
+var DebugZobrist = false
+
+// ZobristHash returns a Zobrist hash of the position, recomputed from scratch using the table.
+// Position.Hash holds the same value, kept up to date incrementally by UnsafeMove.
+func (pos *Position) ZobristHash() uint64 {
+	var hash uint64
+	for sq := 0; sq < numSquaresInBoard; sq++ {
+		hash ^= ZobristKeys[sq][pos.Bd.Piece(Square(sq))]
+	}
+
+	switch pos.Turn {
+	case White:
+		hash ^= whiteHash
+	case Black:
+		hash ^= blackHash
+	}
+
+	return hash
+}
+
+// updateHash incrementally updates the hash for movePiece leaving source, and placedPiece (movePiece, or the promotion
+// piece) replacing capturePiece on destination, followed by the change of side to move.
+func (pos *Position) updateHash(source, destination Square, movePiece, capturePiece, placedPiece Piece) {
+	pos.Hash ^= ZobristKeys[source][movePiece] ^ ZobristKeys[source][NoPiece]
+	pos.Hash ^= ZobristKeys[destination][capturePiece] ^ ZobristKeys[destination][placedPiece]
+	pos.Hash ^= whiteHash ^ blackHash
+}
+
+// checkHash panics if the incrementally updated hash differs from the recomputed hash.
+func (pos *Position) checkHash() {
+	if full := pos.ZobristHash(); pos.Hash != full {
+		panic(fmt.Sprintf("Zobrist hash mismatch: incremental %d, recomputed %d", pos.Hash, full))
+	}
+}
@@ -0,0 +1,63 @@
+package game
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// playRandomGames plays games of random legal moves from the perft positions, at most maxPlies plies each, calling
+// visit with each position and the legal move about to be made there.
+func playRandomGames(t *testing.T, games, maxPlies int, visit func(pos *Position, m Move)) {
+	rng := rand.New(rand.NewSource(1))
+	for g := 0; g < games; g++ {
+		pr := PerftResults[g%len(PerftResults)]
+		pos, err := PositionFromFEN(pr.FEN)
+		if err != nil {
+			t.Fatalf("%s: %v", pr.Name, err)
+		}
+		for ply := 0; ply < maxPlies && pos.Result() == InPlay; ply++ {
+			var ml MoveList
+			pos.GenerateLegalMoveList(&ml)
+			m := ml.Moves[rng.Intn(ml.Count)]
+			visit(pos, m)
+			pos.MakeCompactMove(m)
+		}
+	}
+}
+
+// TestZobristIncremental checks that the incrementally updated hash equals the recomputed one after every move made
+// and taken back in random games, with DebugZobrist on, and that the games included captures, promotions and both
+// sides to move.
+func TestZobristIncremental(t *testing.T) {
+	DebugZobrist = true
+	defer func() { DebugZobrist = false }()
+
+	var captures, promotions, blackToMove int
+	playRandomGames(t, 200, 200, func(pos *Position, m Move) {
+		if got, want := pos.Hash, pos.ZobristHash(); got != want {
+			t.Fatalf("%s: hash %#x, recomputed %#x", pos.FEN(), got, want)
+		}
+		if m.Capture() {
+			captures++
+		}
+		if m.Promotion() != NoPieceType {
+			promotions++
+		}
+		if pos.Turn == Black {
+			blackToMove++
+		}
+
+		u := pos.MakeCompactMove(m)
+		if got, want := pos.Hash, pos.ZobristHash(); got != want {
+			t.Fatalf("%s after %v: hash %#x, recomputed %#x", pos.FEN(), m, got, want)
+		}
+		pos.UnmakeMove(u)
+		if got, want := pos.Hash, pos.ZobristHash(); got != want {
+			t.Fatalf("%s after taking back %v: hash %#x, recomputed %#x", pos.FEN(), m, got, want)
+		}
+	})
+	if captures == 0 || promotions == 0 || blackToMove == 0 {
+		t.Errorf("random games had %d captures, %d promotions and %d positions with Black to move", captures,
+			promotions, blackToMove)
+	}
+}
@@ -0,0 +1,120 @@
+//go:build !gardner && !board8x8
+// +build !gardner,!board8x8
+
+package game
+
+// ZobristKeys holds the random numbers used to calculate the Zobrist hash of a position, indexed by [Square][Piece].
+// Empty squares contribute the NoPiece key.
+var ZobristKeys = [numSquaresInBoard][numPieces]uint64{
+	A1: {11773386922175966982, 16287117459286898574, 1596030731072225293, 6908381805289842349, 1471855846966644088, 13946128460607735265,
+		933481857740680097, 4822007064700398354, 6198724923208203700, 17946889222579085311, 6270309927917970168,
+		15118553941690059193, 7287399143041658278},
+	B1: {1239935007780297662, 10386346689933916061, 12483840290261758634, 16746065366981394713, 12407282130762189405, 17966870885288538231,
+		13698259473969289459, 15755580579568985283, 10146528574378694998, 17720873775737591970, 2472344647272992813,
+		9545319312206432737, 16211094528937730580},
+	C1: {6412973179820290764, 2857391983283342310, 4811709422441345720, 15954483095551476410, 6176328532375587924, 15229555803117540684,
+		6019564927511236213, 5927302832190330030, 4343152757778933172, 1056343617103278623, 573592684560851023,
+		7185503344729520042, 15029395511427455300},
+	D1: {17638576701428372885, 1202785698995402117, 2498941524475391162, 12382573326056141144, 4933516684309646975, 2565226072262439014,
+		9048573062567709169, 12165350819594822749, 3456255634607401815, 9857173880552626642, 1588256792758203023,
+		10816343881309177562, 8877127931527856314},
+	E1: {5658617193308509212, 11525794121241867711, 10536599933475811259, 7939481273209203936, 9457790795157968586, 16190246637726474128,
+		17382097824132401245, 15380453529833825092, 1375799755191178987, 12151254022552636739, 1186296719702877822,
+		11877387608283937289, 5308195320768998020},
+	F1: {1360100442136714265, 13740299683458060087, 8516540595627298802, 13644453717855479901, 17342672294120558105, 15526058230052370786,
+		13344332063336180619, 11718887548006995903, 13524726797816805690, 8184790379158690337, 16533666512202728587,
+		5235164211277995355, 11844581936843340834},
+	A2: {6072176031918769629, 17925223329797815625, 14615379492287389764, 8922529181293848742, 2186575892497350080, 11255819383249683110,
+		9455613954418737896, 8787529630854465939, 15080278555260971654, 10822588433193164194, 6098849504152491340,
+		17219818237252300998, 13580647384350100885},
+	B2: {7629937874342804614, 10638063951634777872, 9527009095712169158, 6014976400866454716, 11093461923709284204, 4104818376804604415,
+		7259830900705720713, 10285184083555264822, 2517620139808330519, 6868627547023817416, 11454128984415816989,
+		4257825623764981408, 773854118806154616},
+	C2: {2566822059360505664, 2646335313407054082, 9957102452461020210, 4602790725508405197, 3344920104025556521, 15982512922683758958,
+		11499171466371752289, 13440786927624358665, 1898715308285314618, 535771651424357824, 11092943512157989895,
+		6486853718769794065, 1953686332845168123},
+	D2: {9569858698258528322, 17642898734978408639, 14359492202908392017, 17655903896892849175, 1330063395150230557, 14620674357213100841,
+		13320464234745932877, 17458510489672546543, 6928323940484480081, 9275827688279428170, 12238427242695405671,
+		3767696847456641062, 2357342663513774537},
+	E2: {10612086517840482733, 11942108215327123125, 16985085901107738691, 7428016039012787895, 346395341476481881, 2161321100412602207,
+		11535305452995508703, 10293468691176545363, 17868059861134303242, 12687427384015048656, 10178860562321799581,
+		2431768293554381321, 10814058511367852521},
+	F2: {5272287977708953033, 1505912239496101634, 2233742591613390424, 322844231205426855, 12094525015859507268, 4162764563160837132,
+		11419309217220353963, 8845411249013691181, 17321360843734533330, 1596953527460594128, 12805506799257378101,
+		9407245939556204087, 5583871741400742328},
+	A3: {16527254978915964266, 16031150834041543796, 3536296654515895987, 1482412321804371265, 15815380194503815770, 11780042619164385330,
+		12226390934754713528, 2624779950949833695, 5204219537379946078, 5630195549636017477, 8992578851473221649,
+		10724134011671535321, 13780037510833876415},
+	B3: {2168322695413405769, 1006578471928546547, 15239214459205079742, 16582141539562978657, 10278894395748860717, 8450509298816644077,
+		6804526565015884229, 4881063388067503983, 15532760663777006515, 4340950135463547861, 12036808534414370123,
+		14251647222397632910, 4566506230261235152},
+	C3: {11428892452587414914, 10231758163641119656, 5348527799442395555, 11260025147690344619, 3333233221876710855, 17140011901125957097,
+		11870358074147416952, 11844390212946384503, 12316106461439024120, 15972260403073356387, 17589983155673301174,
+		3097225612831911107, 14258679338152388051},
+	D3: {9334487589767743786, 14283292896746652930, 1504954434549055802, 10417478159726925200, 11350739486867002710, 10606359796971861698,
+		7385329502892071359, 7139007937393578263, 16668706679756223954, 17909296079823128394, 11506602781942324516,
+		10434910245670606594, 13493135925200288895},
+	E3: {11663905951255169233, 427182107144632515, 7316404717623904185, 14139819536556545541, 12316549601801392658, 6904697297395536213,
+		6789849735265882199, 8055426494981633879, 18202428938912730070, 3061086519933419853, 13212631844504336054,
+		4535329964218158914, 15476126744656767478},
+	F3: {173625333634244122, 13873301547367597972, 7953950595959262141, 18220400653196628710, 9851732300812193879, 10817932178294175381,
+		14513047952726448946, 15821471142276492618, 12773357221497416190, 585931912418038684, 14511326117114479800,
+		4726165785318777112, 17289497737142860773},
+	A4: {17492292331866303211, 5865978329968989632, 3575330857263971148, 5135785284106165524, 17346646487532452867, 17006776898343501925,
+		14761012425106088848, 1133808653343760861, 11705866394343143641, 810765885738391414, 16402293007870477108,
+		10230074703415320641, 6923535644560877481},
+	B4: {4659925661469946721, 1748211115516405655, 8272311948344737425, 11159173626914545888, 4855086305095776812, 661670590802585445,
+		6138305466401791049, 4086856245824389211, 16964749118989876245, 6516335962816781140, 8714844605611571402,
+		6933715686603719315, 3155786775979755774},
+	C4: {9104620987201590065, 6174065723103611825, 15893416705534328851, 6678160223715031183, 8719772738382758561, 17727663361366289203,
+		10764198003842199362, 10767615613795335979, 2907586754536733572, 354039519418428305, 10703033643104006943,
+		261566210149815936, 11354902344506276805},
+	D4: {5124801153691074703, 5844345924858341013, 10538737058250563884, 5503838126036851703, 13249862433994079541, 335841766725459219,
+		11719773066685559593, 1750744690127340514, 810700744967443909, 513256583388648931, 10261952756938359446,
+		1567981304600843281, 1688933402139636945},
+	E4: {4624631714125480411, 3907983101626336110, 11217994730132662973, 10617231302332827099, 12939021186978275046, 1135572291329184406,
+		9755692541292980568, 4755831847496153374, 8755350343101780224, 4038446427481660896, 18375596241001554818,
+		10762944895356380714, 14451515706368962226},
+	F4: {10701091667561070349, 14625645431557203954, 13915509363803329956, 18252245524172127747, 10180003846323696710, 3574696389312514267,
+		16294972208671315245, 980613923437787904, 18400219482907826235, 18426585929219480346, 17962694460894615475,
+		13840113115768224210, 13358788186885209207},
+	A5: {5078965237013387312, 10996549525619867154, 8450082418903396944, 10473422123106913351, 346624852092281243, 8073009176360924998,
+		17934417002597962725, 2015319649236122759, 14747494385568980386, 13074925490434954809, 17969637990791162656,
+		4605012580354036585, 11780282069052880935},
+	B5: {6901648874693365610, 14907669940587679858, 13275990456205445542, 6427450627099029935, 13572279908122645822, 2386384972929881978,
+		2648459995346717954, 6436395110811492248, 14622790242863698231, 9072396571794037216, 12092276400206542330,
+		14527040467027430937, 9606900816542382994},
+	C5: {2773758589587582151, 6703082939502293683, 5430892227613520690, 16797971007539366787, 16561228637489628198, 15886291964460668600,
+		13321945168050029494, 9833906374481384516, 1722726788765740262, 4144203252800625622, 14882892329984662852,
+		840842521541113009, 13971203462538990457},
+	D5: {1571054462922775895, 11693330577297800729, 12864995713890574981, 17932127288754035425, 6892051907783460189, 10994195510102705059,
+		5817031884319575247, 17346603534636869025, 106777638686652344, 5065835324994511329, 3906341670389511792,
+		4127925265169410039, 8692689563807444215},
+	E5: {11727193977121028681, 18180287017938245255, 13042925507364908893, 16705919510350577863, 18047868130386505331, 17346683156043913384,
+		17205067572282183206, 7574146711693955872, 13078297199372302824, 4442906032018553964, 3710350131097370058,
+		15033601019801646129, 10769731414030142434},
+	F5: {14265349171563724632, 14150084214488760745, 14725895992650464589, 9210223609540779732, 11766142961358431158, 13632035142610384552,
+		14119663684533762624, 6853243077979165393, 11082512107468916680, 14703771657165611949, 14794854579590078302,
+		51403218959369684, 17644550649679722540},
+	A6: {5730624484641922827, 4377432308003386796, 96352109645192172, 6125506922645864851, 2073964105513269798, 16890814920110874952,
+		8546445605202623096, 4036538071927409025, 12677955725882282243, 12020999644872683379, 6910592320002715237,
+		2150199153313861083, 14995130368115610173},
+	B6: {15683397801645903382, 7158564836161238555, 6936053491250265091, 3175677080297903372, 17112093807046837214, 13160269491655870207,
+		4895718224712188793, 342238063497134066, 10740151585763382863, 16972109461368274062, 2077955963127131129,
+		12884569016637897509, 4604420141295337282},
+	C6: {3428848391633257808, 2066521119266587960, 5311678803005135959, 12961880221293659866, 11365033512335886647, 16611455622960898934,
+		9401084867147280953, 8228877743144769722, 9551054865368678677, 5367371904371263628, 17725181219846962250,
+		2486157595895798492, 10341579641059727001},
+	D6: {5683895659794663571, 9664435531502648904, 5778951933276307539, 12744509813347914554, 17108694082608486709, 3569944486643882010,
+		11482280678979612811, 3910371669405967471, 14715419190142488886, 7983635611872497642, 10674895694521208845,
+		17045482242207792947, 1717495613409299930},
+	E6: {10458643697206784426, 17966753912542058981, 16482410131381734431, 14988974852773770115, 9495932568727549658, 472014930458144894,
+		11618783473691210058, 3251418374518936931, 15419889733993074151, 253374321517741122, 288575612421696812,
+		16632705428252114975, 15089160924979323871},
+	F6: {15162016773395006737, 15719567261319496588, 10381819373397584044, 245505232520688868, 5096343611318020, 11130238338614010427,
+		6606077363668324549, 8196222139501837355, 12299368809965038468, 15805647502848571893, 11719373776528817052,
+		5714117123790866915, 6796971396725884128},
+}
+
+var whiteHash uint64 = 13616133844141066041
+var blackHash uint64 = 12360302557774378304
@@ -1,15 +1,21 @@
 package main
 
 import (
+	"flag"
+	"math"
+
 	"github.com/an1jay/los-alamos-chess/game"
 	"github.com/an1jay/los-alamos-chess/players"
 )
 
 func main() {
+	pgnFile := flag.String("pgn", "", "append the game played to this PGN file")
+	flag.Parse()
+
 	// defer profile.Start().Stop()
 	// testBoardMove()
 	// timeBBReverse()
-	g := Game{}
+	g := Game{PGNFile: *pgnFile}
 	// g.Play(players.HumanPlayer{}, players.HumanPlayer{}, true)
 
 	ev1 := players.Evaluator{
@@ -52,6 +58,7 @@ func main() {
 var standardmaterialweights = map[game.PieceType]float32{
 	game.Pawn:   1,
 	game.Knight: 3,
+	game.Bishop: 3,
 	game.Rook:   5,
 	game.Queen:  9,
 	game.King:   0,
@@ -60,6 +67,7 @@ var standardmaterialweights = map[game.PieceType]float32{
 var knightrulesweights = map[game.PieceType]float32{
 	game.Pawn:   1,
 	game.Knight: 3.5,
+	game.Bishop: 3.5,
 	game.Rook:   4.5,
 	game.Queen:  8,
 	game.King:   0,
@@ -68,36 +76,30 @@ var knightrulesweights = map[game.PieceType]float32{
 var pawnrulesweights = map[game.PieceType]float32{
 	game.Pawn:   1,
 	game.Knight: 2.5,
+	game.Bishop: 2.5,
 	game.Rook:   3.5,
 	game.Queen:  5,
 	game.King:   0,
 }
 
-var tightcentrecontrolweights = map[game.Square]float32{
-	game.C3: 1,
-	game.C4: 1,
-	game.D3: 1,
-	game.D4: 1,
-}
-
-var loosecentrecontrolweights = map[game.Square]float32{
-	game.C3: 1,
-	game.C4: 1,
-	game.D3: 1,
-	game.D4: 1,
-
-	game.B2: 0.5,
-	game.B3: 0.5,
-	game.B4: 0.5,
-	game.B5: 0.5,
+var tightcentrecontrolweights = centreControlWeights(1, 0)
 
-	game.E2: 0.5,
-	game.E3: 0.5,
-	game.E4: 0.5,
-	game.E5: 0.5,
+var loosecentrecontrolweights = centreControlWeights(1, 0.5)
 
-	game.C2: 0.5,
-	game.C5: 0.5,
-	game.D2: 0.5,
-	game.D5: 0.5,
+// centreControlWeights returns square weights of centre for the centre of the board - the middle 2x2 squares, or the
+// middle square on a board with an odd number of rows - and of around for the ring of squares around them.
+func centreControlWeights(centre, around float32) map[game.Square]float32 {
+	weights := map[game.Square]float32{}
+	mid := float64(game.NumSquaresInRow-1) / 2
+	for sq := 0; sq < game.NumSquaresInBoard; sq++ {
+		f := float64(sq % game.NumSquaresInRow)
+		r := float64(sq / game.NumSquaresInRow)
+		switch d := math.Max(math.Abs(f-mid), math.Abs(r-mid)); {
+		case d < 1:
+			weights[game.Square(sq)] = centre
+		case d < 2 && around != 0:
+			weights[game.Square(sq)] = around
+		}
+	}
+	return weights
 }
@@ -1,3 +1,6 @@
+//go:build !gardner && !board8x8
+// +build !gardner,!board8x8
+
 package main
 
 import (
@@ -52,6 +55,21 @@ func testMove() {
 
 }
 
+func testPerft() {
+	if err := game.CheckPerftResults(4); err != nil {
+		fmt.Println("Perft failed: ", err)
+		return
+	}
+	fmt.Println("Perft counts match")
+
+	pos := game.NewGamePosition()
+	for _, e := range pos.PerftDivide(3) {
+		fmt.Printf("%s: %d\n", pos.SAN(e.Ply), e.Nodes)
+	}
+	start := time.Now()
+	fmt.Printf("Perft(6) = %d in %.02f seconds\n", pos.PerftParallel(6, 6, 20), time.Since(start).Seconds())
+}
+
 func testPseudoLegalMoves() {
 	b := game.BoardFromMap(NewGame)
 	b.Display(false)
@@ -2,26 +2,59 @@ package main
 
 import (
 	"fmt"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/an1jay/los-alamos-chess/game"
+	"github.com/an1jay/los-alamos-chess/players"
 )
 
 // Player defines a struct with a Move method, which can play Los-Alamos-Chess.
+// A player resigns by returning a nil ply.
 type Player interface {
 	ChooseMove(*game.Position) *game.Ply
 }
 
+// TimedPlayer is a Player which can choose a move within a time budget, which a Game with clocks gives it.
+type TimedPlayer interface {
+	Player
+	ChooseMoveWithin(pos *game.Position, budget time.Duration) *game.Ply
+}
+
 // Game is a game of Los Alamos Chess
 type Game struct {
+	// PGNFile, if set, is the file the record of each game played is appended to
+	PGNFile string
+
+	// WhiteClock and BlackClock, if set, are the players' clocks: a TimedPlayer is given the Budget of its clock for
+	// each move, the time each player takes is Spent from its clock, and a player who takes longer than the time left
+	// loses. They are not reset between games.
+	WhiteClock *players.Clock
+	BlackClock *players.Clock
+
 	moveHistory []*game.Ply
 	evList      []float32
+	record      *game.Record
 }
 
-// PlayFromPos plays a game of Los Alamos Chess from given position returing a game.Result
-func (g Game) PlayFromPos(white, black Player, verbose bool, posToPlayFrom *game.Position) game.Result {
+// PlayFromPos plays a game of Los Alamos Chess from given position returing a game.Result and the reason the game ended
+func (g *Game) PlayFromPos(white, black Player, verbose bool, posToPlayFrom *game.Position) (game.Result, game.Termination) {
+	return g.play(white, black, verbose, posToPlayFrom, nil)
+}
+
+// play plays a game from posToPlayFrom as PlayFromPos does, adding the given tags to its record.
+func (g *Game) play(white, black Player, verbose bool, posToPlayFrom *game.Position, tags map[string]string) (game.Result, game.Termination) {
 	// make move list
 	g.moveHistory = []*game.Ply{}
+	g.record = game.NewRecord(posToPlayFrom)
+	for name, value := range tags {
+		g.record.Tags[name] = value
+	}
+	g.record.Tags["White"] = playerName(white)
+	g.record.Tags["Black"] = playerName(black)
 
 	// make new game position
 	pos := posToPlayFrom
@@ -39,24 +72,40 @@ func (g Game) PlayFromPos(white, black Player, verbose bool, posToPlayFrom *game
 
 		// depending on whose move, get move
 		if pos.Turn == game.White {
-			mW := white.ChooseMove(pos.Copy())
+			mW, inTime := chooseMove(white, g.WhiteClock, pos)
+			if !inTime {
+				fmt.Printf("White runs out of time\n\n")
+				return g.end(game.BlackWin, game.Timeout)
+			}
+			if mW == nil {
+				fmt.Printf("White resigns\n\n")
+				return g.end(game.BlackWin, game.Resignation)
+			}
 			g.moveHistory = append(g.moveHistory, mW)
-			if !pos.LegalPly(mW) {
-				fmt.Printf("White plays illegal move - %s\n\n", mW.String())
-				return game.BlackWin
+			if err := pos.ApplyMove(mW); err != nil {
+				fmt.Printf("White plays illegal move - %v\n\n", err)
+				return g.end(game.BlackWin, game.IllegalMove)
 			}
-			pos.Move(mW)
+			g.record.AddPly(mW, "")
 			if verbose {
 				fmt.Printf("White plays %s\n\n", mW.String())
 			}
 		} else if pos.Turn == game.Black {
-			mB := black.ChooseMove(pos.Copy())
+			mB, inTime := chooseMove(black, g.BlackClock, pos)
+			if !inTime {
+				fmt.Printf("Black runs out of time\n\n")
+				return g.end(game.WhiteWin, game.Timeout)
+			}
+			if mB == nil {
+				fmt.Printf("Black resigns\n\n")
+				return g.end(game.WhiteWin, game.Resignation)
+			}
 			g.moveHistory = append(g.moveHistory, mB)
-			if !pos.LegalPly(mB) {
-				fmt.Printf("Black plays illegal move - %s\n\n", mB.String())
-				return game.WhiteWin
+			if err := pos.ApplyMove(mB); err != nil {
+				fmt.Printf("Black plays illegal move - %v\n\n", err)
+				return g.end(game.WhiteWin, game.IllegalMove)
 			}
-			pos.Move(mB)
+			g.record.AddPly(mB, "")
 			if verbose {
 				fmt.Printf("Black plays %s\n\n", mB.String())
 			}
@@ -67,19 +116,108 @@ func (g Game) PlayFromPos(white, black Player, verbose bool, posToPlayFrom *game
 		// }
 
 		// check game over
-		res := pos.Result()
+		res, term := pos.Outcome()
 		if res != game.InPlay {
+			g.end(res, term)
 			if verbose {
 				fmt.Println("")
 				fmt.Println("Game Over! \nFinal Position")
 				fmt.Println("Move History: ", g.moveHistory)
-				fmt.Println(res)
-				fmt.Printf("Full Moves: %d\nHalf-Moves: %d\n", pos.MoveNumber, pos.HalfMoveClock)
+				fmt.Printf("%s by %s\n", res, term)
+				fmt.Printf("Full Moves: %d\nHalf-Moves since capture or pawn move: %d\n", pos.MoveNumber, pos.HalfMoveClock)
 				pos.Display(false)
+				fmt.Println("FEN: ", pos.FEN())
+				fmt.Println(g.record.PGN())
 			}
-			return res
+			return res, term
 		}
 
 	}
 	panic("Game somehow not over")
 }
+
+// PlayFromShuffledStart plays a game of Los Alamos Chess from a shuffled start (see game.ShuffledStart) drawn at
+// random by rng, returning the number of the start - so it can be replayed, e.g. with colours reversed - with the
+// game.Result and the reason the game ended
+func (g *Game) PlayFromShuffledStart(white, black Player, verbose bool, rng *rand.Rand) (int, game.Result, game.Termination) {
+	n := rng.Intn(game.NumShuffledStarts)
+	res, term := g.PlayFromShuffledStartNumber(white, black, verbose, n)
+	return n, res, term
+}
+
+// PlayFromShuffledStartNumber plays a game of Los Alamos Chess from shuffled start #n, recording the number in the
+// record's ShuffledStart tag (the PGN also has the start's FEN unless it is the standard start).
+func (g *Game) PlayFromShuffledStartNumber(white, black Player, verbose bool, n int) (game.Result, game.Termination) {
+	if verbose {
+		fmt.Printf("Shuffled start #%d\n\n", n)
+	}
+	tags := map[string]string{"ShuffledStart": strconv.Itoa(n)}
+	return g.play(white, black, verbose, game.ShuffledStart(n), tags)
+}
+
+// chooseMove asks p to choose a move in a copy of pos, within the budget of its clock if it has one, and returns the
+// move and whether it was chosen before the clock ran out.
+func chooseMove(p Player, clock *players.Clock, pos *game.Position) (*game.Ply, bool) {
+	if clock == nil {
+		return p.ChooseMove(pos.Copy()), true
+	}
+	t0 := time.Now()
+	var m *game.Ply
+	if tp, ok := p.(TimedPlayer); ok {
+		m = tp.ChooseMoveWithin(pos.Copy(), clock.Budget())
+	} else {
+		m = p.ChooseMove(pos.Copy())
+	}
+	used := time.Since(t0)
+	inTime := used <= clock.Remaining
+	clock.Spend(used)
+	return m, inTime
+}
+
+// end records the result of the game and why it ended, saving the record if there is a PGNFile, and returns them.
+func (g *Game) end(res game.Result, term game.Termination) (game.Result, game.Termination) {
+	g.record.Result = res
+	g.record.Termination = term
+	if g.PGNFile != "" {
+		if err := g.SavePGN(g.PGNFile, nil); err != nil {
+			fmt.Println("Could not save game: ", err)
+		}
+	}
+	return res, term
+}
+
+// playerName returns the name of a player's type, e.g. "Neo" for a *players.Neo, for the PGN player tags.
+func playerName(p Player) string {
+	name := fmt.Sprintf("%T", p)
+	return name[strings.LastIndex(name, ".")+1:]
+}
+
+// Record returns the record of the last game played, or nil if no game has been played.
+func (g *Game) Record() *game.Record {
+	return g.record
+}
+
+// SavePGN appends the record of the last game played, with the given tags (e.g. "Event") added to the players' names,
+// to a PGN file.
+func (g *Game) SavePGN(filename string, tags map[string]string) error {
+	if g.record == nil {
+		return fmt.Errorf("no game has been played")
+	}
+	for name, value := range tags {
+		g.record.Tags[name] = value
+	}
+	if _, ok := g.record.Tags["Date"]; !ok {
+		g.record.Tags["Date"] = time.Now().Format("2006.01.02")
+	}
+
+	f, err := os.OpenFile(filename, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if err := g.record.WritePGN(f); err != nil {
+		return err
+	}
+	_, err = f.WriteString("\n")
+	return err
+}
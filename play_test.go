@@ -0,0 +1,54 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/an1jay/los-alamos-chess/game"
+	"github.com/an1jay/los-alamos-chess/players"
+)
+
+// budgetResigner is a TimedPlayer which takes a while and then resigns, noting the budget it was given.
+type budgetResigner struct {
+	think  time.Duration
+	budget time.Duration
+}
+
+func (r *budgetResigner) ChooseMove(pos *game.Position) *game.Ply {
+	time.Sleep(r.think)
+	return nil
+}
+
+func (r *budgetResigner) ChooseMoveWithin(pos *game.Position, budget time.Duration) *game.Ply {
+	r.budget = budget
+	return r.ChooseMove(pos)
+}
+
+// TestGameClocks checks that a Game gives a TimedPlayer its clock's budget, spends the time it takes from the clock,
+// and ends the game when a player runs out of time.
+func TestGameClocks(t *testing.T) {
+	clock := &players.Clock{Remaining: time.Second, Increment: 100 * time.Millisecond, MovesToGo: 10}
+	budget := clock.Budget()
+	white := &budgetResigner{think: 10 * time.Millisecond}
+	g := Game{WhiteClock: clock}
+	if res, term := g.PlayFromPos(white, &resigner{}, false, game.NewGamePosition()); res != game.BlackWin ||
+		term != game.Resignation {
+		t.Errorf("game ended %s by %s, want a resignation", res, term)
+	}
+	if white.budget != budget {
+		t.Errorf("budget = %v, want %v", white.budget, budget)
+	}
+	if clock.Remaining > time.Second+90*time.Millisecond || clock.Remaining < time.Second || clock.MovesToGo != 9 {
+		t.Errorf("after a 10ms move clock is %+v", clock)
+	}
+
+	g = Game{BlackClock: &players.Clock{Remaining: time.Millisecond}}
+	pos, err := game.PositionFromFEN(strings.Replace(game.StartingFEN, " w ", " b ", 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res, term := g.PlayFromPos(&resigner{}, white, false, pos); res != game.WhiteWin || term != game.Timeout {
+		t.Errorf("game ended %s by %s, want a loss on time", res, term)
+	}
+}
@@ -35,3 +35,20 @@ func (ev Evaluator) Evaluate(pos *game.Position) float32 {
 	}
 	return score
 }
+
+// Score returns Evaluate from the point of view of the side to move.
+// Implements the SearchEvaluator interface.
+func (ev Evaluator) Score(pos *game.Position) float32 {
+	return ev.Evaluate(pos) * float32(pos.Turn.Coefficient())
+}
+
+// PieceValue returns the contribution of a piece of type pt to the evaluation.
+// Implements the PieceValuer interface.
+func (ev Evaluator) PieceValue(pt game.PieceType) float32 {
+	return ev.MaterialCoeff * ev.MaterialWeights[pt]
+}
+
+// SEE returns the static exchange evaluation of the capture p in pos, using the Evaluator's MaterialWeights.
+func (ev Evaluator) SEE(pos *game.Position, p *game.Ply) float32 {
+	return pos.Bd.SEE(p, ev.MaterialWeights)
+}
@@ -11,27 +11,41 @@ type HumanPlayer struct{}
 
 // ChooseMove asks for a legal move through stdout/stdin
 func (h HumanPlayer) ChooseMove(pos *game.Position) *game.Ply {
-	fmt.Println("Legal Moves: ", pos.GenerateLegalMoves())
+	var legalMoves []string
+	for _, lgm := range pos.GenerateLegalMoves() {
+		legalMoves = append(legalMoves, pos.SAN(lgm))
+	}
+	fmt.Println("Legal Moves: ", legalMoves)
 	p := &game.Ply{}
 	for true {
-		fmt.Println("Enter Move (e.g. 'e5xd6=Knight' denotes a capture from e5 to e6, with promotion to Knight): ")
+		fmt.Println("Enter Move (e.g. 'exd6=N', or 'e5xd6=Knight' which denotes a capture from e5 to d6, with promotion to Knight): ")
 		var move string
 		_, err := fmt.Scan(&move)
-		if err != nil || len(move) < 6 {
+		if err != nil {
+			fmt.Println("Try Again")
+			continue
+		}
+		sanPly, sanErr := pos.ParseSAN(move)
+		if sanErr == nil {
+			p = sanPly
+			break
+		}
+		if len(move) < 6 {
+			fmt.Println(sanErr)
 			fmt.Println("Try Again")
 			continue
 		}
 		p.SourceSq = game.StringToSquareMap[move[0:2]]
 		p.DestinationSq = game.StringToSquareMap[move[3:5]]
-		if move[2] == 'x' {
-			p.Capture = true
-		}
+		p.Capture = move[2] == 'x'
 		p.Side = pos.Turn
 		p.Promotion = game.PieceTypeFromString(move[6:])
 
-		if pos.LegalPly(p) {
+		err = pos.CheckMove(p)
+		if err == nil {
 			break
 		}
+		fmt.Println(err)
 		fmt.Println("Try Again")
 	}
 	return p
@@ -0,0 +1,133 @@
+package players
+
+import (
+	"time"
+
+	"github.com/an1jay/los-alamos-chess/game"
+)
+
+// Clock is the time a player has left to play their moves. A game keeps one for each player, giving each a Budget
+// for choosing a move and taking the time used off with Spend. For example, 40 moves in 5 minutes, repeated until
+// the game ends, is
+//
+//	Clock{Remaining: 5 * time.Minute, MovesToGo: 40, PeriodMoves: 40, PeriodTime: 5 * time.Minute}
+type Clock struct {
+	// Remaining is the time left on the clock
+	Remaining time.Duration
+
+	// Increment is the time added to the clock after each move
+	Increment time.Duration
+
+	// MovesToGo is the number of moves to play before the next time control, or 0 if there is none
+	MovesToGo uint
+
+	// PeriodMoves and PeriodTime are the moves and time of the period after a time control: when MovesToGo reaches 0,
+	// PeriodTime is added and MovesToGo starts again from PeriodMoves - so the same period repeats, or if PeriodMoves
+	// is 0 the rest of the game is played in the time then remaining.
+	PeriodMoves uint
+	PeriodTime  time.Duration
+}
+
+// defaultMovesToGo is the number of moves a game is assumed to last for when the clock does not say
+const defaultMovesToGo = 20
+
+// Budget returns the time to spend choosing the next move: an equal share of the remaining time over the moves still
+// to play, plus most of the increment - but never more than half the remaining time.
+func (c *Clock) Budget() time.Duration {
+	movesToGo := c.MovesToGo
+	if movesToGo == 0 {
+		movesToGo = defaultMovesToGo
+	}
+	budget := c.Remaining/time.Duration(movesToGo) + c.Increment*3/4
+	if limit := c.Remaining / 2; budget > limit {
+		budget = limit
+	}
+	return budget
+}
+
+// Spend takes the time used to choose a move off the clock, and adds the increment - and the next period's time
+// when the move reaches a time control.
+func (c *Clock) Spend(used time.Duration) {
+	c.Remaining += c.Increment - used
+	if c.MovesToGo == 0 {
+		return
+	}
+	c.MovesToGo--
+	if c.MovesToGo == 0 {
+		c.Remaining += c.PeriodTime
+		c.MovesToGo = c.PeriodMoves
+	}
+}
+
+//--------------------------------------------------------------------------------
+
+// winScore is the value of a won position, at which iterative deepening stops as searching deeper cannot do better
+var winScore = game.Result(game.WhiteWin).Evaluation()
+
+// SearchResult is the outcome of IterativeDeepening.
+type SearchResult struct {
+	// Move is the best move found at the deepest completed depth, nil if there are no legal moves
+	Move *game.Ply
+	// Score is the value of Move, from the point of view of the side to move
+	Score float32
+	// Depth is the deepest completed search, in plies including Move
+	Depth uint
+	// Nodes is the number of positions visited, including by an unfinished last iteration
+	Nodes uint
+	// Time is how long the search took
+	Time time.Duration
+}
+
+// IterativeDeepening searches pos to a depth of 1 ply, then 2, and so on up to maxDepth, trying the best move of
+// each depth first at the next. It stops when budget has passed, or when half of it has passed before starting
+// a new depth (which would take longer than all the previous ones), returning the best move of the deepest completed
+// depth - the first depth is always completed. The Search's Deadline is overwritten.
+func (s *Search) IterativeDeepening(pos *game.Position, maxDepth uint, budget time.Duration) SearchResult {
+	start := s.timeNow()
+	res := SearchResult{}
+	moves := pos.GenerateLegalMoves()
+	s.Nodes = 0
+
+	for depth := uint(1); depth <= maxDepth && len(moves) > 0; depth++ {
+		if depth == 1 {
+			s.Deadline = time.Time{}
+		} else {
+			s.Deadline = start.Add(budget)
+		}
+		best, score := s.searchRoot(pos, moves, depth-1)
+		if s.stopped {
+			break
+		}
+		res.Move, res.Score, res.Depth = moves[best], score, depth
+
+		// try the best move first at the next depth
+		copy(moves[1:best+1], moves[:best])
+		moves[0] = res.Move
+
+		if score >= winScore || score <= -winScore || s.timeNow().Sub(start) > budget/2 {
+			break
+		}
+	}
+	res.Nodes = s.Nodes
+	res.Time = s.timeNow().Sub(start)
+	return res
+}
+
+// searchRoot searches each move in turn to depth plies after it, narrowing the window as better moves are found, and
+// returns the index of the first best move with its value from the point of view of the side to move.
+func (s *Search) searchRoot(pos *game.Position, moves []*game.Ply, depth uint) (int, float32) {
+	s.stopped = false
+	best, alpha := 0, -DefaultVal
+	for i, m := range moves {
+		u := pos.MakeMove(m)
+		score := -s.negamax(pos, depth, 0, -DefaultVal, -alpha)
+		pos.UnmakeMove(u)
+		if s.stopped {
+			break
+		}
+		if score > alpha {
+			best, alpha = i, score
+		}
+	}
+	return best, alpha
+}
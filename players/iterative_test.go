@@ -0,0 +1,97 @@
+package players
+
+import (
+	"testing"
+	"time"
+
+	"github.com/an1jay/los-alamos-chess/game"
+)
+
+// TestClock checks the budgets a clock gives and the time it keeps through a game: sudden death, with an increment,
+// and with time controls which add time every so many moves or only once.
+func TestClock(t *testing.T) {
+	const s = time.Second
+	type step struct {
+		used, budget, remaining time.Duration
+		movesToGo               uint
+	}
+	tests := []struct {
+		name  string
+		clock Clock
+		steps []step
+	}{
+		{
+			"sudden death",
+			Clock{Remaining: 20 * s},
+			[]step{{s / 2, s, 19*s + s/2, 0}, {s, s - s/40, 18*s + s/2, 0}},
+		},
+		{
+			"increment",
+			Clock{Remaining: 20 * s, Increment: 2 * s},
+			[]step{{3 * s, s + 3*s/2, 19 * s, 0}, {s, 19*s/20 + 3*s/2, 20 * s, 0}},
+		},
+		{
+			// each period's time is added when its moves are played
+			"2 moves in 10s, repeated",
+			Clock{Remaining: 10 * s, MovesToGo: 2, PeriodMoves: 2, PeriodTime: 10 * s},
+			[]step{{5 * s, 5 * s, 5 * s, 1}, {5 * s / 2, 5 * s / 2, 25 * s / 2, 2}, {s, 25 * s / 4, 23 * s / 2, 1}},
+		},
+		{
+			// the last period has no time control
+			"2 moves in 10s, then 5s for the rest",
+			Clock{Remaining: 10 * s, MovesToGo: 2, PeriodTime: 5 * s},
+			[]step{{5 * s, 5 * s, 5 * s, 1}, {s, 5 * s / 2, 9 * s, 0}, {s, 9 * s / 20, 8 * s, 0}, {s, 8 * s / 20, 7 * s, 0}},
+		},
+	}
+	for _, test := range tests {
+		c := test.clock
+		for i, st := range test.steps {
+			if got := c.Budget(); got != st.budget {
+				t.Errorf("%s: move %d budget = %v, want %v", test.name, i+1, got, st.budget)
+			}
+			c.Spend(st.used)
+			if c.Remaining != st.remaining || c.MovesToGo != st.movesToGo {
+				t.Errorf("%s: after move %d, clock %+v, want %v left with %d moves to go", test.name, i+1, c,
+					st.remaining, st.movesToGo)
+			}
+		}
+	}
+}
+
+// TestIterativeDeepeningStopped checks that when the budget runs out part way through a depth, the move returned is
+// the best move of the deepest completed depth, not one from the unfinished search. The search reads a clock which
+// moves on a millisecond each time it is read - every 1024 nodes - so it stops at the same node on every run.
+func TestIterativeDeepeningStopped(t *testing.T) {
+	pos := game.NewGamePosition()
+	stops := 0
+	for budget := 2 * time.Millisecond; budget <= time.Second && stops < 3; budget = budget * 5 / 4 {
+		var elapsed time.Duration
+		s := Search{Ev: testEvaluator, now: func() time.Time {
+			elapsed += time.Millisecond
+			return time.Time{}.Add(elapsed)
+		}}
+		res := s.IterativeDeepening(pos, 20, budget)
+		if !s.Stopped() {
+			continue
+		}
+		stops++
+		t.Logf("budget %v: stopped after %d nodes, at depth %d", budget, res.Nodes, res.Depth+1)
+		if res.Move == nil || res.Depth == 0 {
+			t.Fatalf("budget %v: stopped without a completed depth", budget)
+		}
+
+		// the move's value searched afresh must be the value of the position at the completed depth
+		fresh := Search{Ev: testEvaluator}
+		want := fresh.Negamax(pos, res.Depth, -DefaultVal, DefaultVal)
+		u := pos.MakeMove(res.Move)
+		got := -fresh.Negamax(pos, res.Depth-1, -DefaultVal, DefaultVal)
+		pos.UnmakeMove(u)
+		if res.Score != want || got != want {
+			t.Errorf("budget %v, depth %d: returned %v with score %v, worth %v, best score %v", budget, res.Depth,
+				res.Move, res.Score, got, want)
+		}
+	}
+	if stops == 0 {
+		t.Error("no search was stopped by its budget")
+	}
+}
@@ -0,0 +1,64 @@
+package players
+
+import "github.com/an1jay/los-alamos-chess/game"
+
+// maxHistory is the largest history a move may have before all histories are halved
+const maxHistory int32 = 1 << 20
+
+// moveOrder is what a Search has learnt about which quiet moves cause cutoffs. It is the game.QuietScorer of the
+// Search's game.MovePickers.
+type moveOrder struct {
+	// killers are the last two quiet moves to cause a cutoff at each ply, most recent first
+	killers [][2]game.Move
+	// history is indexed by side, source and destination square - no board has more than 64 squares
+	history [2][64][64]int32
+}
+
+// cutoff records that the quiet move m by side caused a cutoff at ply with depth plies left to search.
+func (mo *moveOrder) cutoff(m game.Move, side game.Color, ply, depth uint) {
+	for uint(len(mo.killers)) <= ply {
+		mo.killers = append(mo.killers, [2]game.Move{})
+	}
+	if k := &mo.killers[ply]; k[0] != m {
+		k[1], k[0] = k[0], m
+	}
+
+	h := &mo.history[side][m.Source()][m.Destination()]
+	*h += int32(depth * depth)
+	if *h > maxHistory {
+		for side := range mo.history {
+			for from := range mo.history[side] {
+				for to := range mo.history[side][from] {
+					mo.history[side][from][to] /= 2
+				}
+			}
+		}
+	}
+}
+
+// killersAt returns the killer moves at ply.
+func (mo *moveOrder) killersAt(ply uint) [2]game.Move {
+	if ply < uint(len(mo.killers)) {
+		return mo.killers[ply]
+	}
+	return [2]game.Move{}
+}
+
+// ScoreQuiet returns the history of the quiet move m by side, for game.QuietScorer.
+func (mo *moveOrder) ScoreQuiet(side game.Color, m game.Move) int32 {
+	return mo.history[side][m.Source()][m.Destination()]
+}
+
+// initMovePicker prepares mp for the legal moves of pos at ply, with ttMove first. Without move ordering the moves
+// are handed out in the order generated - as when searching full width, where the order makes no difference.
+func (s *Search) initMovePicker(mp *game.MovePicker, pos *game.Position, ttMove game.Move, ply uint) {
+	mp.Unordered = s.NoMoveOrdering || s.FullWidth
+	if mp.Unordered {
+		mp.Init(pos, ttMove, [2]game.Move{}, nil)
+		return
+	}
+	if s.order == nil {
+		s.order = &moveOrder{}
+	}
+	mp.Init(pos, ttMove, s.order.killersAt(ply), s.order)
+}
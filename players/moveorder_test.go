@@ -0,0 +1,75 @@
+package players
+
+import (
+	"testing"
+
+	"github.com/an1jay/los-alamos-chess/game"
+)
+
+// TestMoveOrdering checks that ordering the moves, with and without a transposition table, searches fewer nodes than
+// searching them in the order generated, and that ordering alone gives the same values.
+func TestMoveOrdering(t *testing.T) {
+	const depth = 4
+	var totals [3]uint
+	for _, pr := range game.PerftResults {
+		searches := []Search{
+			{Ev: testEvaluator, NoMoveOrdering: true},
+			{Ev: testEvaluator},
+			{Ev: testEvaluator, TT: NewTranspositionTable(16)},
+		}
+		var values [3]float32
+		for i := range searches {
+			pos, err := game.PositionFromFEN(pr.FEN)
+			if err != nil {
+				t.Fatalf("%s: %v", pr.Name, err)
+			}
+			values[i] = searches[i].Negamax(pos, depth, -DefaultVal, DefaultVal)
+			totals[i] += searches[i].Nodes
+		}
+		if values[1] != values[0] {
+			t.Errorf("%s: ordered value %v, unordered %v", pr.Name, values[1], values[0])
+		}
+		t.Logf("%-16s unordered %6d, ordered %6d, ordered with TT %6d nodes", pr.Name, searches[0].Nodes,
+			searches[1].Nodes, searches[2].Nodes)
+	}
+	if totals[1] >= totals[0] || totals[2] >= totals[0] {
+		t.Errorf("searched %d nodes unordered, %d ordered and %d ordered with a TT", totals[0], totals[1], totals[2])
+	}
+}
+
+// BenchmarkMoveOrdering searches the perft positions to a fixed depth with the moves unordered, ordered, and ordered
+// with a transposition table (cleared, untimed, before each search), reporting the nodes each search visits on average.
+func BenchmarkMoveOrdering(b *testing.B) {
+	const depth = 4
+	configs := []struct {
+		name   string
+		search Search
+	}{
+		{"unordered", Search{Ev: testEvaluator, NoMoveOrdering: true}},
+		{"ordered", Search{Ev: testEvaluator}},
+		{"ordered+TT", Search{Ev: testEvaluator, TT: NewTranspositionTable(16)}},
+	}
+	for _, config := range configs {
+		b.Run(config.name, func(b *testing.B) {
+			var nodes, searches uint
+			for i := 0; i < b.N; i++ {
+				for _, pr := range game.PerftResults {
+					pos, err := game.PositionFromFEN(pr.FEN)
+					if err != nil {
+						b.Fatalf("%s: %v", pr.Name, err)
+					}
+					s := config.search
+					if s.TT != nil {
+						b.StopTimer()
+						s.TT.Clear()
+						b.StartTimer()
+					}
+					s.Negamax(pos, depth, -DefaultVal, DefaultVal)
+					nodes += s.Nodes
+					searches++
+				}
+			}
+			b.ReportMetric(float64(nodes)/float64(searches), "nodes/search")
+		})
+	}
+}
@@ -0,0 +1,207 @@
+package players
+
+import (
+	"time"
+
+	"github.com/an1jay/los-alamos-chess/game"
+)
+
+// SearchEvaluator scores positions for Search.
+type SearchEvaluator interface {
+	// Score returns the evaluation of pos from the point of view of the side to move - positive when pos.Turn is
+	// better off - including positions where the game is over.
+	Score(pos *game.Position) float32
+}
+
+// Search is a negamax alpha-beta search: every node maximises its value from the point of view of its side to move,
+// which is the negation of its children's values, so both sides are searched by the same code.
+// All the search functions in this package are built on it.
+type Search struct {
+	Ev SearchEvaluator
+
+	// Nodes counts the positions visited, including those evaluated without being searched further
+	Nodes uint
+
+	// FullWidth searches every move without alpha-beta cutoffs, giving the plain minimax value
+	FullWidth bool
+
+	// Quiescence searches captures and promotions at the end of the search until the position is quiet, instead of
+	// evaluating positions in the middle of an exchange. QuiescenceChecks also searches quiet moves which give check
+	// at the first ply of the quiescence search, and captures which leave the side to move more than DeltaMargin
+	// short of alpha are pruned (when Ev is a PieceValuer and DeltaMargin is not zero).
+	Quiescence       bool
+	QuiescenceChecks bool
+	DeltaMargin      float32
+
+	// MaxPly, if not zero, stops the quiescence search at MaxPly plies below the start of the search
+	MaxPly uint
+
+	// TT, if set, stores search results to be reused when a position is reached again, and may be shared with other
+	// Searches
+	TT *TranspositionTable
+
+	// NoMoveOrdering searches moves in the order they are generated (after any transposition table move), instead of
+	// trying the moves most likely to cause a cutoff first
+	NoMoveOrdering bool
+	order          *moveOrder
+
+	// Deadline stops the search once passed, unless zero. The values returned by a stopped search are meaningless.
+	Deadline time.Time
+	stopped  bool
+	// now returns the time the Deadline is checked against, time.Now if nil - tests replace it to stop searches at
+	// a chosen node
+	now func() time.Time
+}
+
+// timeCheckInterval is the number of nodes searched between checks of the deadline, minus one
+const timeCheckInterval = 1023
+
+// NewSearch returns an alpha-beta search using ev.
+func NewSearch(ev SearchEvaluator) *Search {
+	return &Search{Ev: ev}
+}
+
+// Negamax returns the value of pos searched to depth plies, from the point of view of the side to move.
+// Values are fail-soft: a value <= alpha is an upper bound on the true value, and a value >= beta a lower bound.
+func (s *Search) Negamax(pos *game.Position, depth uint, alpha, beta float32) float32 {
+	s.stopped = false
+	return s.negamax(pos, depth, 0, alpha, beta)
+}
+
+// negamax is Negamax for a node ply plies below the start of the search.
+func (s *Search) negamax(pos *game.Position, depth, ply uint, alpha, beta float32) float32 {
+	if depth == 0 && s.Quiescence {
+		return s.quiesce(pos, ply, 0, alpha, beta)
+	}
+	if !s.visit() {
+		return 0
+	}
+	// if at a terminal node, evaluate:
+	if depth == 0 || pos.Result() != game.InPlay {
+		return s.Ev.Score(pos)
+	}
+
+	alphaOrig := alpha
+	ttMove := game.NoMove
+	if s.TT != nil {
+		if e, ok := s.TT.Probe(pos.Hash); ok {
+			if e.Depth >= depth && !s.FullWidth {
+				switch {
+				case e.Bound == ExactBound,
+					e.Bound == LowerBound && e.Score >= beta,
+					e.Bound == UpperBound && e.Score <= alpha:
+					return e.Score
+				}
+			}
+			ttMove = e.Move
+		}
+	}
+
+	value := -DefaultVal
+	var bestMove game.Move
+	var mp game.MovePicker
+	s.initMovePicker(&mp, pos, ttMove, ply)
+	for m, ok := mp.Next(); ok; m, ok = mp.Next() {
+		u := pos.MakeCompactMove(m)
+		score := -s.negamax(pos, depth-1, ply+1, -beta, -alpha)
+		pos.UnmakeMove(u)
+		if s.stopped {
+			return 0
+		}
+		if score > value {
+			value = score
+			bestMove = m
+		}
+		alpha = max(alpha, value)
+		if alpha >= beta && !s.FullWidth {
+			if s.order != nil && !m.Capture() && m.Promotion() == game.NoPieceType {
+				s.order.cutoff(m, pos.Turn, ply, depth)
+			}
+			break
+		}
+	}
+
+	if s.TT != nil {
+		e := TTEntry{Score: value, Bound: ExactBound, Depth: depth, Move: bestMove}
+		switch {
+		case value <= alphaOrig:
+			e.Bound, e.Move = UpperBound, game.NoMove
+		case value >= beta:
+			e.Bound = LowerBound
+		}
+		s.TT.Store(pos.Hash, e)
+	}
+	return value
+}
+
+// visit counts a node, and returns false if the search has been stopped - checking the deadline every so often.
+func (s *Search) visit() bool {
+	s.Nodes++
+	if s.Nodes&timeCheckInterval == 0 && !s.Deadline.IsZero() && s.timeNow().After(s.Deadline) {
+		s.stopped = true
+	}
+	return !s.stopped
+}
+
+// timeNow returns the current time from now, or time.Now.
+func (s *Search) timeNow() time.Time {
+	if s.now == nil {
+		return time.Now()
+	}
+	return s.now()
+}
+
+// moveToFront moves the ply matching m, if there is one, to the front of plies, keeping the order of the rest.
+func moveToFront(plies []*game.Ply, m game.Move) {
+	if m == game.NoMove {
+		return
+	}
+	for i, p := range plies {
+		if game.MoveFromPly(p) == m {
+			copy(plies[1:i+1], plies[:i])
+			plies[0] = p
+			return
+		}
+	}
+}
+
+// Stopped returns whether the search was stopped by its Deadline.
+func (s *Search) Stopped() bool {
+	return s.stopped
+}
+
+// ScoreMoves makes each move from pos in turn and returns its value searched to depth plies after it, from the point
+// of view of the side to move in pos, within the window (alpha, beta) for that side.
+func (s *Search) ScoreMoves(pos *game.Position, moves []*game.Ply, depth uint, alpha, beta float32) []float32 {
+	s.stopped = false
+	scores := make([]float32, len(moves))
+	for i, m := range moves {
+		u := pos.MakeMove(m)
+		scores[i] = -s.negamax(pos, depth, 0, -beta, -alpha)
+		pos.UnmakeMove(u)
+	}
+	return scores
+}
+
+// bestMoves returns the moves with the highest score.
+func bestMoves(moves []*game.Ply, scores []float32) []*game.Ply {
+	bestScore := -DefaultVal
+	for _, scr := range scores {
+		bestScore = max(bestScore, scr)
+	}
+	var best []*game.Ply
+	for i, scr := range scores {
+		if scr == bestScore {
+			best = append(best, moves[i])
+		}
+	}
+	return best
+}
+
+// sideWindow converts a window of values from White's point of view to side's point of view.
+func sideWindow(side game.Color, alpha, beta float32) (float32, float32) {
+	if side == game.Black {
+		return -beta, -alpha
+	}
+	return alpha, beta
+}
@@ -10,9 +10,11 @@ import (
 
 // Neo is a faster AI
 type Neo struct {
-	MinDepth        uint
-	MaxDepth        uint
-	Ev              *Evaluator
+	MinDepth uint
+	MaxDepth uint
+	Ev       *Evaluator
+	// TT, if set, is shared by Neo's searchers and kept from move to move
+	TT              *TranspositionTable
 	positionQueue   chan moveAndPosition
 	evaluationQueue chan evaluation
 	wg              *sync.WaitGroup
@@ -33,18 +35,72 @@ func CreateNewNeo(minDepth uint, maxDepth uint, ev *Evaluator, threadCount int,
 
 	for i := 0; i < threadCount; i++ {
 		go positionSearcher(NN.positionQueue, NN.evaluationQueue, NN.wg,
-			NN.MinDepth, NN.MaxDepth, NN.Ev, verbose)
+			NN.MaxDepth, NN.Ev, verbose)
 	}
 
 	return NN
 }
 
-// ChooseMove asks Neo to choose a move
+// ChooseMove asks Neo to choose a move, searching every move MinDepth plies
 func (n *Neo) ChooseMove(pos *game.Position) *game.Ply {
 	t0 := time.Now()
 	fmt.Println("Neo thinks...")
 
+	if n.TT != nil {
+		n.TT.NextGeneration()
+	}
+
+	legalMoves := pos.GenerateLegalMoves()
+	bestMove, _, nodecnt, _ := n.searchMoves(pos, legalMoves, n.MinDepth, time.Time{})
+	dt := time.Since(t0).Seconds()
+	fmt.Printf("Neo explored %d nodes in %.02f seconds at %.02f nodes/s \n", nodecnt, dt, float64(nodecnt)/dt)
+	return bestMove
+}
+
+// ChooseMoveWithin asks Neo to choose a move by iterative deepening, as Search.IterativeDeepening but searching the
+// moves of each depth concurrently, for at most budget and up to MaxDepth plies
+func (n *Neo) ChooseMoveWithin(pos *game.Position, budget time.Duration) *game.Ply {
+	t0 := time.Now()
+	fmt.Println("Neo thinks...")
+
+	if n.TT != nil {
+		n.TT.NextGeneration()
+	}
+
 	legalMoves := pos.GenerateLegalMoves()
+	var deadline time.Time
+	var bestMove *game.Ply
+	var depthReached, totalNodes uint
+	for depth := uint(1); depth <= n.MaxDepth && len(legalMoves) > 0; depth++ {
+		// the first depth is always completed
+		if depth > 1 {
+			deadline = t0.Add(budget)
+		}
+		move, score, nodecnt, stopped := n.searchMoves(pos, legalMoves, depth-1, deadline)
+		totalNodes += nodecnt
+		if stopped {
+			break
+		}
+		bestMove, depthReached = move, depth
+
+		// send the best move to the searchers first at the next depth
+		moveToFront(legalMoves, game.MoveFromPly(move))
+
+		if score >= winScore || score <= -winScore || time.Since(t0) > budget/2 {
+			break
+		}
+	}
+
+	dt := time.Since(t0).Seconds()
+	fmt.Printf("Neo reached depth %d, exploring %d nodes in %.02f seconds at %.02f nodes/s \n",
+		depthReached, totalNodes, dt, float64(totalNodes)/dt)
+	return bestMove
+}
+
+// searchMoves has the searchers search each move to depth plies after it, and returns the best move with its score
+// from the point of view of the side to move, and the number of nodes searched. If the deadline (unless zero)
+// passed before every move was searched, stopped is true and the best move is meaningless.
+func (n *Neo) searchMoves(pos *game.Position, legalMoves []*game.Ply, depth uint, deadline time.Time) (bestMove *game.Ply, bestScore float32, nodecnt uint, stopped bool) {
 	numLegalMoves := len(legalMoves)
 
 	for _, lgm := range legalMoves {
@@ -52,8 +108,11 @@ func (n *Neo) ChooseMove(pos *game.Position) *game.Ply {
 		newPos := pos.Copy()
 		newPos.UnsafeMove(lgm)
 		n.positionQueue <- moveAndPosition{
-			move: *lgm,
-			pos:  newPos,
+			move:     *lgm,
+			pos:      newPos,
+			depth:    depth,
+			deadline: deadline,
+			tt:       n.TT,
 		}
 	}
 
@@ -61,7 +120,6 @@ func (n *Neo) ChooseMove(pos *game.Position) *game.Ply {
 
 	var reorderLegalMoves = make([]game.Ply, numLegalMoves)
 	var reorderMoveScores = make([]float32, numLegalMoves)
-	var nodecnt uint
 	var counter int
 
 	// for e := range n.evaluationQueue {
@@ -78,6 +136,7 @@ func (n *Neo) ChooseMove(pos *game.Position) *game.Ply {
 			reorderLegalMoves[counter] = item.move
 			reorderMoveScores[counter] = item.eval
 			nodecnt += item.nodecnt
+			stopped = stopped || item.stopped
 			counter++
 			ok = true
 		default:
@@ -85,29 +144,29 @@ func (n *Neo) ChooseMove(pos *game.Position) *game.Ply {
 		}
 	}
 
-	var bestScore = float32(pos.Turn.Other().Coefficient()) * DefaultVal
-	var bestMove *game.Ply
-
+	bestScore = -DefaultVal
 	for i := 0; i < numLegalMoves; i++ {
-		if sideGeqLeq(pos.Turn, reorderMoveScores[i], bestScore) {
+		if reorderMoveScores[i] >= bestScore {
 			bestScore = reorderMoveScores[i]
 			bestMove = &reorderLegalMoves[i]
 		}
 	}
-
-	dt := time.Since(t0).Seconds()
-	fmt.Printf("Neo explored %d nodes in %.02f seconds at %.02f nodes/s \n", nodecnt, dt, float64(nodecnt)/dt)
-	return bestMove
+	return bestMove, bestScore, nodecnt, stopped
 }
 
 func positionSearcher(in chan moveAndPosition, out chan evaluation, wg *sync.WaitGroup,
-	minDepth, maxDepth uint, ev *Evaluator, verbose bool) {
+	maxDepth uint, ev *Evaluator, verbose bool) {
 	for candidateNode := range in {
-		val, ndct := MinimaxAlphaBetaQuiescenceConcurrently(minDepth, maxDepth, 0, candidateNode.pos.Turn, candidateNode.pos, ev, -1*DefaultVal, DefaultVal)
+		// each searcher has its own Search, as a Search is not safe for concurrent use
+		s := Search{Ev: ev, Quiescence: true, DeltaMargin: DefaultDeltaMargin, MaxPly: maxDepth,
+			TT: candidateNode.tt, Deadline: candidateNode.deadline}
+		// the position is after the move, so its side to move is the opponent of the side choosing the move
+		val := -s.Negamax(candidateNode.pos, candidateNode.depth, -DefaultVal, DefaultVal)
 		message := evaluation{
 			move:    candidateNode.move,
-			nodecnt: ndct,
+			nodecnt: s.Nodes,
 			eval:    val,
+			stopped: s.Stopped(),
 		}
 		if verbose {
 			fmt.Println(message.String())
@@ -0,0 +1,82 @@
+package players
+
+import "github.com/an1jay/los-alamos-chess/game"
+
+// PieceValuer is implemented by SearchEvaluators which can say what a piece is worth, in the units of Score. The
+// quiescence search uses it for delta pruning.
+type PieceValuer interface {
+	PieceValue(pt game.PieceType) float32
+}
+
+// DefaultDeltaMargin is a DeltaMargin for an Evaluator valuing a pawn at about 1, allowing for the positional terms
+// changing by up to a pawn and a half after a capture
+const DefaultDeltaMargin float32 = 1.5
+
+// quiesce returns the value of pos, a node ply plies below the start of the search and qply plies into the quiescence
+// search, searching only captures and promotions (and quiet checks at the first quiescence ply, if QuiescenceChecks)
+// until the position is quiet. The side to move may stand pat - take the static evaluation instead of capturing -
+// unless in check, when every move is searched.
+func (s *Search) quiesce(pos *game.Position, ply, qply uint, alpha, beta float32) float32 {
+	if !s.visit() {
+		return 0
+	}
+	if pos.Result() != game.InPlay || (s.MaxPly > 0 && ply >= s.MaxPly) {
+		return s.Ev.Score(pos)
+	}
+
+	value := -DefaultVal
+	var standPat float32
+	if !pos.InCheck {
+		standPat = s.Ev.Score(pos)
+		if standPat >= beta {
+			return standPat
+		}
+		value = standPat
+		alpha = max(alpha, standPat)
+	}
+
+	// delta pruning: skip captures which could not raise the static evaluation to alpha even with the margin
+	pv, deltaPruning := s.Ev.(PieceValuer)
+	deltaPruning = deltaPruning && s.DeltaMargin > 0 && !pos.InCheck
+
+	var mp game.MovePicker
+	mp.Unordered = s.NoMoveOrdering
+	mp.InitTactical(pos)
+	for m, ok := mp.Next(); ok; m, ok = mp.Next() {
+		if deltaPruning && m.Promotion() == game.NoPieceType &&
+			standPat+pv.PieceValue(pos.Bd.Piece(m.Destination()).PieceType())+s.DeltaMargin <= alpha {
+			continue
+		}
+		u := pos.MakeCompactMove(m)
+		score := -s.quiesce(pos, ply+1, qply+1, -beta, -alpha)
+		pos.UnmakeMove(u)
+		if s.stopped {
+			return 0
+		}
+		value = max(value, score)
+		alpha = max(alpha, value)
+		if alpha >= beta {
+			return value
+		}
+	}
+
+	if s.QuiescenceChecks && qply == 0 && !pos.InCheck {
+		var ml game.MoveList
+		pos.GenerateQuietMoveList(&ml)
+		for _, m := range ml.Slice() {
+			u := pos.MakeCompactMove(m)
+			if pos.InCheck {
+				value = max(value, -s.quiesce(pos, ply+1, qply+1, -beta, -alpha))
+			}
+			pos.UnmakeMove(u)
+			if s.stopped {
+				return 0
+			}
+			alpha = max(alpha, value)
+			if alpha >= beta {
+				break
+			}
+		}
+	}
+	return value
+}
@@ -0,0 +1,67 @@
+package players
+
+import (
+	"testing"
+
+	"github.com/an1jay/los-alamos-chess/game"
+)
+
+// queenTakesDefendedPawn is a 6x6 position where White's queen can take the pawn on d4, which the pawn on e5 defends.
+const queenTakesDefendedPawn = "r4k/p3p1/3p2/2Q3/PP4/K5 w - - 0 1"
+
+// TestQuiescenceRejectsDefendedPawn checks that a one-ply search sees the queen lost after taking a defended pawn
+// only with the quiescence search.
+func TestQuiescenceRejectsDefendedPawn(t *testing.T) {
+	if game.NumSquaresInRow != 6 {
+		t.Skip("the position is on the 6x6 board")
+	}
+	pos, err := game.PositionFromFEN(queenTakesDefendedPawn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	moves := pos.GenerateLegalMoves()
+	for _, quiescence := range []bool{false, true} {
+		s := Search{Ev: testEvaluator, Quiescence: quiescence, DeltaMargin: DefaultDeltaMargin}
+		best := bestMoves(moves, s.ScoreMoves(pos, moves, 0, -DefaultVal, DefaultVal))
+		takes := false
+		for _, p := range best {
+			takes = takes || pos.SAN(p) == "Qxd4"
+		}
+		if takes == quiescence {
+			t.Errorf("with Quiescence %v, best moves include Qxd4: %v", quiescence, takes)
+		}
+	}
+}
+
+// TestDeltaPruning checks that delta pruning with DefaultDeltaMargin does not change the value of searches from the
+// perft positions and a position with a poisoned capture.
+func TestDeltaPruning(t *testing.T) {
+	fens := []string{}
+	for _, pr := range game.PerftResults {
+		fens = append(fens, pr.FEN)
+	}
+	if game.NumSquaresInRow == 6 {
+		fens = append(fens, queenTakesDefendedPawn)
+	}
+	var prunedNodes, fullNodes uint
+	for _, fen := range fens {
+		pos, err := game.PositionFromFEN(fen)
+		if err != nil {
+			t.Fatalf("%s: %v", fen, err)
+		}
+		for depth := uint(1); depth <= 3; depth++ {
+			pruned := Search{Ev: testEvaluator, Quiescence: true, DeltaMargin: DefaultDeltaMargin}
+			full := Search{Ev: testEvaluator, Quiescence: true}
+			got := pruned.Negamax(pos, depth, -DefaultVal, DefaultVal)
+			want := full.Negamax(pos, depth, -DefaultVal, DefaultVal)
+			if got != want {
+				t.Errorf("%s, depth %d: value %v with delta pruning, %v without", fen, depth, got, want)
+			}
+			prunedNodes += pruned.Nodes
+			fullNodes += full.Nodes
+		}
+	}
+	if prunedNodes >= fullNodes {
+		t.Errorf("searched %d nodes with delta pruning, %d without", prunedNodes, fullNodes)
+	}
+}
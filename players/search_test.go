@@ -0,0 +1,63 @@
+package players
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/an1jay/los-alamos-chess/game"
+)
+
+// testEvaluator is the evaluator the tests and benchmarks search with, like those in main.go.
+var testEvaluator = &Evaluator{
+	MaterialCoeff:   1,
+	LegalMovesCoeff: 0.1,
+	MaterialWeights: map[game.PieceType]float32{
+		game.Pawn:   1,
+		game.Knight: 2.5,
+		game.Bishop: 2.5,
+		game.Rook:   3.5,
+		game.Queen:  5,
+	},
+}
+
+// BenchmarkSearch searches the starting position with MinimaxAlphaBetaQuiescenceConcurrently, the search each of
+// Neo's searchers runs, reporting the nodes searched per second - most of the time goes on move generation and the
+// mobility evaluation, which the sliding attack lookups dominate.
+func BenchmarkSearch(b *testing.B) {
+	var nodes uint
+	for i := 0; i < b.N; i++ {
+		pos := game.NewGamePosition()
+		_, n := MinimaxAlphaBetaQuiescenceConcurrently(4, 8, 0, pos.Turn, pos, testEvaluator, -DefaultVal, DefaultVal)
+		nodes += n
+	}
+	b.ReportMetric(float64(nodes)/b.Elapsed().Seconds(), "nodes/s")
+}
+
+// TestMinimaxAlphaBeta checks that alpha-beta pruning does not change the value of a search, from White's point of
+// view, with either side to move in each of the perft positions (which include the setups in boardsetups.go).
+func TestMinimaxAlphaBeta(t *testing.T) {
+	const depth = 3
+	for _, pr := range game.PerftResults {
+		bd, err := game.BoardFromFEN(strings.Fields(pr.FEN)[0])
+		if err != nil {
+			t.Fatalf("%s: %v", pr.Name, err)
+		}
+		for _, side := range []game.Color{game.White, game.Black} {
+			pos := game.NewPosition(bd.Copy(), side, 0, 0, nil)
+			if pos.Validate() != nil {
+				// the side not to move is in check
+				continue
+			}
+			var minimaxNodes, alphaBetaNodes uint
+			want := Minimax(depth, side, pos.Copy(), &minimaxNodes, testEvaluator)
+			got := MinimaxAlphaBeta(depth, side, pos.Copy(), &alphaBetaNodes, testEvaluator, -DefaultVal, DefaultVal)
+			if got != want {
+				t.Errorf("%s, %s to move: MinimaxAlphaBeta = %v, Minimax = %v", pr.Name, side, got, want)
+			}
+			if alphaBetaNodes > minimaxNodes {
+				t.Errorf("%s, %s to move: MinimaxAlphaBeta searched %d nodes, Minimax %d", pr.Name, side,
+					alphaBetaNodes, minimaxNodes)
+			}
+		}
+	}
+}
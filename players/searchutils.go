@@ -2,6 +2,7 @@ package players
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/an1jay/los-alamos-chess/game"
 )
@@ -23,20 +24,6 @@ func min(x, y float32) float32 {
 	return y
 }
 
-func sideMinMax(side game.Color, x, y float32) float32 {
-	if side == game.White {
-		return max(x, y)
-	}
-	return min(x, y)
-}
-
-func sideGeqLeq(side game.Color, x, y float32) bool {
-	if side == game.White {
-		return x >= y
-	}
-	return x <= y
-}
-
 func umax(a, b uint) uint {
 	if a > b {
 		return a
@@ -55,12 +42,20 @@ func sumUintSlice(arr []uint) uint {
 type evaluation struct {
 	move    game.Ply
 	nodecnt uint
-	eval    float32
+	// eval is from the point of view of the side making move
+	eval float32
+	// stopped is set if the search passed its deadline, making eval meaningless
+	stopped bool
 }
 
 type moveAndPosition struct {
 	pos  *game.Position
 	move game.Ply
+	// depth is the number of plies to search after move, until deadline (unless zero)
+	depth    uint
+	deadline time.Time
+	// tt is the transposition table to search with, if any
+	tt *TranspositionTable
 }
 
 func (ev evaluation) String() string {
@@ -0,0 +1,152 @@
+package players
+
+import (
+	"math"
+	"sync/atomic"
+
+	"github.com/an1jay/los-alamos-chess/game"
+)
+
+// Bound says how a score stored in a TranspositionTable relates to the true value of the position.
+type Bound uint8
+
+const (
+	// NoBound marks an empty entry
+	NoBound Bound = iota
+	// ExactBound scores are the value of the position
+	ExactBound
+	// LowerBound scores failed high - the value of the position is at least the score
+	LowerBound
+	// UpperBound scores failed low - the value of the position is at most the score
+	UpperBound
+)
+
+// TTEntry is what a TranspositionTable knows about a position.
+type TTEntry struct {
+	// Score is the value of the position searched to Depth plies, from the point of view of the side to move
+	Score float32
+	Bound Bound
+	Depth uint
+	// Move is the best move found, NoMove if none was (e.g. every move failed low)
+	Move game.Move
+}
+
+// TranspositionTable is a fixed-size hash table of search results, keyed by Zobrist hash, which may be shared by
+// searches running in several goroutines at once without locking.
+//
+// Each bucket holds two entries: the first keeps the deepest search of the current generation, the second the most
+// recent search which did not replace the first. Each entry stores its data (score, move, depth, bound and
+// generation) and the key XORed with the data, so an entry torn by concurrent writes fails to match and is ignored.
+//
+// Positions with the same pieces and side to move share an entry whatever their history, so scores may be slightly
+// off where repetitions or the no-progress rule matter.
+type TranspositionTable struct {
+	buckets    []ttBucket
+	mask       uint64
+	generation uint64
+}
+
+type ttBucket [2]ttEntry
+
+type ttEntry struct {
+	check uint64
+	data  uint64
+}
+
+// Bit layout of ttEntry.data: bits 0-31 the score, 32-47 the move, 48-55 the depth, 56-57 the bound and 58-63 the
+// generation
+const (
+	ttMoveShift       = 32
+	ttDepthShift      = 48
+	ttBoundShift      = 56
+	ttGenerationShift = 58
+
+	ttMaxDepth       = 1<<8 - 1
+	ttGenerationMask = 1<<6 - 1
+)
+
+// bytesPerBucket is the size of a ttBucket
+const bytesPerBucket = 32
+
+// NewTranspositionTable returns an empty table using at most megabytes MiB (the number of buckets is rounded down to
+// a power of two), and at least one bucket.
+func NewTranspositionTable(megabytes uint) *TranspositionTable {
+	n := uint64(1)
+	for n*2*bytesPerBucket <= uint64(megabytes)<<20 {
+		n *= 2
+	}
+	return &TranspositionTable{
+		buckets: make([]ttBucket, n),
+		mask:    n - 1,
+	}
+}
+
+// Clear empties the table. It must not be called while the table is being searched with.
+func (tt *TranspositionTable) Clear() {
+	for i := range tt.buckets {
+		tt.buckets[i] = ttBucket{}
+	}
+	tt.generation = 0
+}
+
+// NextGeneration marks the entries already in the table as old, so that they are replaced first. Call it before
+// each new search from the root, while no search is using the table.
+func (tt *TranspositionTable) NextGeneration() {
+	tt.generation = (tt.generation + 1) & ttGenerationMask
+}
+
+// Probe returns the entry for the position with the hash, and whether there is one.
+func (tt *TranspositionTable) Probe(hash uint64) (TTEntry, bool) {
+	b := &tt.buckets[hash&tt.mask]
+	for i := range b {
+		data := atomic.LoadUint64(&b[i].data)
+		if atomic.LoadUint64(&b[i].check)^data == hash && Bound(data>>ttBoundShift&3) != NoBound {
+			return TTEntry{
+				Score: math.Float32frombits(uint32(data)),
+				Bound: Bound(data >> ttBoundShift & 3),
+				Depth: uint(data >> ttDepthShift & ttMaxDepth),
+				Move:  game.Move(data >> ttMoveShift),
+			}, true
+		}
+	}
+	return TTEntry{}, false
+}
+
+// Store records the result of searching the position with the hash. An earlier entry for the same position is
+// overwritten, but its move is kept if the new result has none.
+func (tt *TranspositionTable) Store(hash uint64, e TTEntry) {
+	b := &tt.buckets[hash&tt.mask]
+	depth := e.Depth
+	if depth > ttMaxDepth {
+		depth = ttMaxDepth
+	}
+
+	// choose the entry to overwrite: the position's own, else the first if it is old or no deeper, else the second
+	var slot *ttEntry
+	for i := range b {
+		data := atomic.LoadUint64(&b[i].data)
+		if atomic.LoadUint64(&b[i].check)^data == hash {
+			slot = &b[i]
+			if e.Move == game.NoMove {
+				e.Move = game.Move(data >> ttMoveShift)
+			}
+			break
+		}
+	}
+	if slot == nil {
+		data := atomic.LoadUint64(&b[0].data)
+		if data>>ttGenerationShift != tt.generation || uint(data>>ttDepthShift&ttMaxDepth) <= depth {
+			slot = &b[0]
+		} else {
+			slot = &b[1]
+		}
+	}
+
+	data := uint64(math.Float32bits(e.Score)) |
+		uint64(e.Move)<<ttMoveShift |
+		uint64(depth)<<ttDepthShift |
+		uint64(e.Bound)<<ttBoundShift |
+		tt.generation<<ttGenerationShift
+	atomic.StoreUint64(&slot.data, data)
+	atomic.StoreUint64(&slot.check, hash^data)
+}
@@ -0,0 +1,160 @@
+package players
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/an1jay/los-alamos-chess/game"
+)
+
+// TestTranspositionTableRoundTrip checks that entries are read back as stored, with the depth clamped to what an
+// entry can hold.
+func TestTranspositionTableRoundTrip(t *testing.T) {
+	tt := NewTranspositionTable(1)
+	move := game.NewMove(game.Square(1), game.Square(8), game.NoPieceType, true)
+	tests := []struct {
+		hash uint64
+		in   TTEntry
+		want TTEntry
+	}{
+		{1, TTEntry{Score: 1.5, Bound: ExactBound, Depth: 3, Move: move}, TTEntry{1.5, ExactBound, 3, move}},
+		{2, TTEntry{Score: -2.75, Bound: LowerBound, Depth: 0}, TTEntry{-2.75, LowerBound, 0, game.NoMove}},
+		{3, TTEntry{Score: -100, Bound: UpperBound, Depth: 255, Move: move}, TTEntry{-100, UpperBound, 255, move}},
+		{4, TTEntry{Score: 0.1, Bound: ExactBound, Depth: 1000, Move: move}, TTEntry{0.1, ExactBound, 255, move}},
+		{0, TTEntry{Score: 7, Bound: LowerBound, Depth: 2, Move: move}, TTEntry{7, LowerBound, 2, move}},
+	}
+	for _, test := range tests {
+		tt.Store(test.hash, test.in)
+	}
+	for _, test := range tests {
+		if got, ok := tt.Probe(test.hash); !ok || got != test.want {
+			t.Errorf("Probe(%d) = %+v, %v, want %+v", test.hash, got, ok, test.want)
+		}
+	}
+
+	if got, ok := tt.Probe(5); ok {
+		t.Errorf("Probe of a position never stored = %+v", got)
+	}
+	// a position in the same bucket as a stored one does not match it
+	if got, ok := tt.Probe(1 + uint64(len(tt.buckets))); ok {
+		t.Errorf("Probe of a position sharing a bucket = %+v", got)
+	}
+
+	tt.Clear()
+	for _, test := range tests {
+		if got, ok := tt.Probe(test.hash); ok {
+			t.Errorf("Probe(%d) after Clear = %+v", test.hash, got)
+		}
+	}
+}
+
+// TestTranspositionTableOverwrite checks that storing a position again replaces its entry, keeping the earlier move
+// only if the new result has none, and that an entry with NoBound is never found.
+func TestTranspositionTableOverwrite(t *testing.T) {
+	tt := NewTranspositionTable(1)
+	first := game.NewMove(game.Square(1), game.Square(8), game.NoPieceType, false)
+	second := game.NewMove(game.Square(2), game.Square(9), game.NoPieceType, false)
+
+	tt.Store(42, TTEntry{Score: 1, Bound: LowerBound, Depth: 4, Move: first})
+	tt.Store(42, TTEntry{Score: -3, Bound: UpperBound, Depth: 2})
+	if got, want := probe(tt, 42), (TTEntry{-3, UpperBound, 2, first}); got != want {
+		t.Errorf("after storing without a move, entry = %+v, want %+v", got, want)
+	}
+	tt.Store(42, TTEntry{Score: 5, Bound: ExactBound, Depth: 6, Move: second})
+	if got, want := probe(tt, 42), (TTEntry{5, ExactBound, 6, second}); got != want {
+		t.Errorf("after storing with a move, entry = %+v, want %+v", got, want)
+	}
+
+	// the position has one entry, so one store of NoBound hides it
+	tt.Store(42, TTEntry{Score: 5, Bound: NoBound, Depth: 6, Move: second})
+	if got, ok := tt.Probe(42); ok {
+		t.Errorf("Probe after storing NoBound = %+v", got)
+	}
+}
+
+// TestTranspositionTableReplacement checks which entry of a bucket a new position replaces: the first if it is from
+// an earlier generation or no deeper, otherwise the second.
+func TestTranspositionTableReplacement(t *testing.T) {
+	// a table of a single bucket, so that every position shares it
+	tt := NewTranspositionTable(0)
+	if len(tt.buckets) != 1 {
+		t.Fatalf("NewTranspositionTable(0) has %d buckets, want 1", len(tt.buckets))
+	}
+	store := func(hash uint64, depth uint) {
+		tt.Store(hash, TTEntry{Score: float32(hash), Bound: ExactBound, Depth: depth})
+	}
+	check := func(step string, present ...uint64) {
+		for hash := uint64(1); hash <= 5; hash++ {
+			want := false
+			for _, h := range present {
+				want = want || h == hash
+			}
+			if _, ok := tt.Probe(hash); ok != want {
+				t.Errorf("%s: Probe(%d) found %v, want %v", step, hash, ok, want)
+			}
+		}
+	}
+
+	store(1, 5)
+	store(2, 3)
+	check("shallower search kept in second entry", 1, 2)
+	store(3, 2)
+	check("second entry replaced by most recent", 1, 3)
+	store(4, 5)
+	check("first entry replaced by as deep a search", 4, 3)
+	tt.NextGeneration()
+	store(5, 1)
+	check("first entry replaced when from an earlier generation", 5, 3)
+}
+
+// TestTranspositionTableConcurrent stores and probes from several goroutines at once - run it with -race - checking
+// that every entry found is one which was stored for the position, never a mixture of two.
+func TestTranspositionTableConcurrent(t *testing.T) {
+	// a small table, so that the goroutines often write to the same buckets
+	tt := NewTranspositionTable(0)
+	entryFor := func(hash uint64) TTEntry {
+		return TTEntry{
+			Score: float32(hash%1000) - 500,
+			Bound: Bound(hash%3) + ExactBound,
+			Depth: uint(hash % 64),
+			Move:  game.Move(hash%1000 + 1),
+		}
+	}
+
+	const goroutines, hashes = 8, 2000
+	var wg sync.WaitGroup
+	var found int64
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < hashes; i++ {
+				hash := uint64(i*goroutines+g) * 0x9E3779B97F4A7C15
+				tt.Store(hash, entryFor(hash))
+				if got, ok := tt.Probe(hash); ok {
+					atomic.AddInt64(&found, 1)
+					if got != entryFor(hash) {
+						t.Errorf("Probe(%#x) = %+v, want %+v", hash, got, entryFor(hash))
+					}
+				}
+				for j := 0; j <= i; j += 97 {
+					other := uint64(j*goroutines+(g+1)%goroutines) * 0x9E3779B97F4A7C15
+					if got, ok := tt.Probe(other); ok && got != entryFor(other) {
+						t.Errorf("Probe(%#x) = %+v, want %+v", other, got, entryFor(other))
+					}
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+	if found == 0 {
+		t.Error("no stored entry was found")
+	}
+}
+
+// probe returns the table's entry for hash, the zero TTEntry if it has none.
+func probe(tt *TranspositionTable, hash uint64) TTEntry {
+	e, _ := tt.Probe(hash)
+	return e
+}
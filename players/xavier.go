@@ -12,14 +12,37 @@ type Xavier struct {
 	MinDepth uint
 	MaxDepth uint
 	Ev       *Evaluator
+	// TT, if set, is used by Xavier's searches and kept from move to move
+	TT *TranspositionTable
 }
 
-// ChooseMove asks Xavier to choose a move
+// ChooseMove asks Xavier to choose a move, searching every move MinDepth plies
 func (x *Xavier) ChooseMove(pos *game.Position) *game.Ply {
 	t0 := time.Now()
 	fmt.Println("Xavier thinks...")
-	mv, nodecnt := ChooseMinimaxAlphaBetaQuiescence(pos, x.Ev, x.MinDepth, x.MaxDepth, -1*DefaultVal, DefaultVal)
+	s := x.newSearch()
+	legalMoves := pos.GenerateLegalMoves()
+	mv := bestMoves(legalMoves, s.ScoreMoves(pos, legalMoves, x.MinDepth, -DefaultVal, DefaultVal))
+	nodecnt := s.Nodes
 	dt := time.Since(t0).Seconds()
 	fmt.Printf("Xavier explored %d nodes in %.02f seconds at %.02f nodes/s \n", nodecnt, dt, float64(nodecnt)/dt)
 	return mv[0]
 }
+
+// ChooseMoveWithin asks Xavier to choose a move by iterative deepening, for at most budget and up to MaxDepth plies
+func (x *Xavier) ChooseMoveWithin(pos *game.Position, budget time.Duration) *game.Ply {
+	fmt.Println("Xavier thinks...")
+	s := x.newSearch()
+	res := s.IterativeDeepening(pos, x.MaxDepth, budget)
+	fmt.Printf("Xavier reached depth %d, exploring %d nodes in %.02f seconds at %.02f nodes/s \n",
+		res.Depth, res.Nodes, res.Time.Seconds(), float64(res.Nodes)/res.Time.Seconds())
+	return res.Move
+}
+
+// newSearch returns the Search for Xavier's next move, starting a new generation of its TT.
+func (x *Xavier) newSearch() *Search {
+	if x.TT != nil {
+		x.TT.NextGeneration()
+	}
+	return &Search{Ev: x.Ev, Quiescence: true, DeltaMargin: DefaultDeltaMargin, MaxPly: x.MaxDepth, TT: x.TT}
+}
@@ -1,5 +1,13 @@
 package main
 
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+
+	"github.com/an1jay/los-alamos-chess/game"
+)
+
 // struct Tournament{
 
 // }
@@ -7,3 +15,34 @@ package main
 // func UpdateGlicko() {
 
 // }
+
+// PlayMatch plays pairs of games between a and b, each pair from a shuffled start drawn by a rand.Rand seeded with
+// seed - so the same seed replays the same starts - with a playing White in the first game of the pair and Black in
+// the second. Each game's record has its round and shuffled start number as tags, and is saved if there is a
+// PGNFile. It returns a's score, counting 1 for a win and 0.5 for a draw.
+func (g *Game) PlayMatch(a, b Player, pairs int, seed int64, verbose bool) float32 {
+	rng := rand.New(rand.NewSource(seed))
+	var score float32
+	for i := 0; i < pairs; i++ {
+		n := rng.Intn(game.NumShuffledStarts)
+		for j, aColor := range []game.Color{game.White, game.Black} {
+			white, black := a, b
+			if aColor == game.Black {
+				white, black = b, a
+			}
+			round := fmt.Sprintf("%d.%d", i+1, j+1)
+			if verbose {
+				fmt.Printf("Round %s, shuffled start #%d\n\n", round, n)
+			}
+			tags := map[string]string{"Round": round, "ShuffledStart": strconv.Itoa(n)}
+			res, _ := g.play(white, black, verbose, game.ShuffledStart(n), tags)
+			switch {
+			case res == game.Draw:
+				score += 0.5
+			case res == game.WhiteWin && aColor == game.White, res == game.BlackWin && aColor == game.Black:
+				score++
+			}
+		}
+	}
+	return score
+}
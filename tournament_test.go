@@ -0,0 +1,51 @@
+package main
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/an1jay/los-alamos-chess/game"
+)
+
+// resigner resigns at once, noting the shuffled start of each game it is asked to move in.
+type resigner struct {
+	starts []int
+}
+
+func (r *resigner) ChooseMove(pos *game.Position) *game.Ply {
+	r.starts = append(r.starts, game.ShuffledStartNumber(pos.Bd))
+	return nil
+}
+
+// TestPlayMatchShuffledStarts checks that a match plays each shuffled start twice, with colours reversed, replays the
+// same starts from the same seed, and tags the records with the start.
+func TestPlayMatchShuffledStarts(t *testing.T) {
+	const pairs = 3
+	var starts [2][]int
+	for i := range starts {
+		a, b := &resigner{}, &resigner{}
+		g := Game{}
+		// White resigns every game, so a wins only the games it plays Black
+		if score := g.PlayMatch(a, b, pairs, 42, false); score != pairs {
+			t.Errorf("score = %v, want %v", score, pairs)
+		}
+		if len(a.starts) != pairs || len(b.starts) != pairs {
+			t.Fatalf("a moved in %d games and b in %d, want %d each", len(a.starts), len(b.starts), pairs)
+		}
+		for j := range a.starts {
+			if a.starts[j] != b.starts[j] || a.starts[j] < 0 {
+				t.Errorf("pair %d played from shuffled starts %d and %d", j+1, a.starts[j], b.starts[j])
+			}
+		}
+		if got, want := g.Record().Tags["ShuffledStart"], strconv.Itoa(a.starts[pairs-1]); got != want {
+			t.Errorf("ShuffledStart tag = %q, want %q", got, want)
+		}
+		starts[i] = a.starts
+	}
+	for j := range starts[0] {
+		if starts[0][j] != starts[1][j] {
+			t.Errorf("same seed gave shuffled starts %v and %v", starts[0], starts[1])
+			break
+		}
+	}
+}